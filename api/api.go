@@ -0,0 +1,9 @@
+// Package api embeds the OpenAPI specification of the DOB API.
+package api
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 document in openapi.json.
+//
+//go:embed openapi.json
+var OpenAPI []byte
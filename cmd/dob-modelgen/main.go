@@ -0,0 +1,36 @@
+// Command dob-modelgen generates the DOB API client's model structs from
+// the API's OpenAPI document. It is run by go generate in
+// internal/provider/client.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"terraform-provider-devops/internal/openapi"
+)
+
+func main() {
+	spec := flag.String("spec", "api/openapi.json", "OpenAPI document to generate from")
+	pkg := flag.String("package", "client", "package of the generated file")
+	out := flag.String("o", "models_gen.go", "file to write")
+	flag.Parse()
+
+	b, err := os.ReadFile(*spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	doc, err := openapi.Load(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := doc.GenerateModels(*pkg, filepath.Base(*spec))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
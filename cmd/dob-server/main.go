@@ -0,0 +1,82 @@
+// Command dob-server runs the in-memory DOB API for local development.
+//
+//	go run ./cmd/dob-server -addr :8080 -data dob.json
+//
+// Without -data the state lives only as long as the process. With -data
+// the state is loaded from the file on start, seeded from -seed if the file
+// does not exist yet, and written back after every change.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"terraform-provider-devops/internal/dobserver"
+)
+
+func main() {
+	var (
+		addr     string
+		dataFile string
+		seedFile string
+		pageSize int
+		versions string
+		legacy   bool
+	)
+
+	flag.StringVar(&addr, "addr", ":8080", "address to listen on")
+	flag.StringVar(&dataFile, "data", "", "JSON file to persist state to")
+	flag.StringVar(&seedFile, "seed", "", "JSON snapshot to start from when there is no data file")
+	flag.IntVar(&pageSize, "page-size", 0, "paginate list responses with this many items per page")
+	flag.StringVar(&versions, "api-versions", "", "comma-separated API versions to offer, such as v1; all by default")
+	flag.BoolVar(&legacy, "legacy", false, "serve only unversioned routes, without /meta, like an API that predates versioning")
+	flag.Parse()
+
+	opts := dobserver.Options{DataFile: dataFile, PageSize: pageSize, Legacy: legacy}
+	if versions != "" {
+		opts.APIVersions = strings.Split(versions, ",")
+	}
+	if seedFile != "" {
+		seed, err := dobserver.LoadSnapshot(seedFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.Seed = seed
+	}
+
+	srv, err := dobserver.New(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("DOB API listening on %s", addr)
+	s := &http.Server{
+		Addr:              addr,
+		Handler:           logRequests(srv),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Fatal(s.ListenAndServe())
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func logRequests(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(rec, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Microsecond))
+	})
+}
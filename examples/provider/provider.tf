@@ -1,3 +1,4 @@
-provider "scaffolding" {
-  # example configuration here
+provider "dob" {
+  endpoint = "https://dob.example.com"
+  token    = var.dob_token
 }
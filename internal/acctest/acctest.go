@@ -0,0 +1,166 @@
+// Package acctest holds the shared setup of the provider's acceptance tests.
+//
+// By default the tests run against an in-process DOB API (see
+// internal/dobserver) seeded with testdata/seed.json, so `make testacc`
+// needs no external service. Set DOB_ACC_ENDPOINT to run them against a
+// real API instead.
+//
+// Set DOB_ACC_CASSETTES=record to record each test's API traffic to
+// testdata/cassettes/<TestName>.json in the test's package, and
+// DOB_ACC_CASSETTES=replay to run the tests from those cassettes without
+// any API at all.
+package acctest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"terraform-provider-devops/internal/cassette"
+	"terraform-provider-devops/internal/dobserver"
+	"terraform-provider-devops/internal/provider"
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+const (
+	// EndpointEnvVar names the environment variable that points the
+	// acceptance tests at an external API.
+	EndpointEnvVar = "DOB_ACC_ENDPOINT"
+	// CassetteModeEnvVar names the environment variable that selects
+	// whether the tests record or replay cassettes.
+	CassetteModeEnvVar = "DOB_ACC_CASSETTES"
+)
+
+// replayEndpoint is the endpoint configured while replaying. Nothing is
+// ever sent to it.
+const replayEndpoint = "http://dob.invalid"
+
+//go:embed testdata/seed.json
+var seedJSON []byte
+
+// ProtoV6ProviderFactories instantiates the provider under test, recording
+// or replaying t's API traffic if DOB_ACC_CASSETTES asks for it.
+func ProtoV6ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+	var opts []client.Option
+	if rec := recorder(t); rec != nil {
+		opts = append(opts, client.WithTransportWrapper(rec.Wrap))
+	}
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"dob": providerserver.NewProtocol6WithError(provider.NewWithClientOptions("test", opts...)()),
+	}
+}
+
+// NewClient returns an API client for checks that act on the API outside
+// Terraform. It shares t's cassette with the provider.
+func NewClient(t *testing.T) (*client.Client, error) {
+	t.Helper()
+	var opts []client.Option
+	if rec := recorder(t); rec != nil {
+		opts = append(opts, client.WithTransportWrapper(rec.Wrap))
+	}
+	endpoint := Endpoint()
+	return client.NewClient(&endpoint, opts...)
+}
+
+// ProviderConfig returns a provider block for the acceptance test API.
+func ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "dob" {
+    endpoint = %q
+}
+`, Endpoint())
+}
+
+var (
+	endpointOnce sync.Once
+	endpoint     string
+)
+
+// Endpoint returns the URL of the API the acceptance tests use, starting
+// the in-process server on first use. The server lives until the test
+// binary exits.
+func Endpoint() string {
+	endpointOnce.Do(func() {
+		if v := os.Getenv(EndpointEnvVar); v != "" {
+			endpoint = v
+			return
+		}
+		if os.Getenv(CassetteModeEnvVar) == string(cassette.ModeReplay) {
+			endpoint = replayEndpoint
+			return
+		}
+
+		srv, err := dobserver.New(dobserver.Options{Seed: seed()})
+		if err != nil {
+			panic(fmt.Sprintf("acctest: starting DOB API: %v", err))
+		}
+		endpoint = httptest.NewServer(srv).URL
+	})
+	return endpoint
+}
+
+func seed() *dobserver.Snapshot {
+	var s dobserver.Snapshot
+	if err := json.Unmarshal(seedJSON, &s); err != nil {
+		panic(fmt.Sprintf("acctest: decoding seed: %v", err))
+	}
+	return &s
+}
+
+// cassetteOptions returns the options cassettes are recorded with. Seed
+// emails are fixture data that tests check for verbatim.
+func cassetteOptions() cassette.Options {
+	var keep []string
+	for _, e := range seed().Engineers {
+		keep = append(keep, e.Email)
+	}
+	return cassette.Options{KeepEmails: keep}
+}
+
+// recorders holds the cassette recorder of each running test.
+var recorders sync.Map
+
+// recorder returns t's cassette recorder, or nil when cassettes are off.
+func recorder(t *testing.T) *cassette.Recorder {
+	t.Helper()
+	mode, err := cassette.ParseMode(os.Getenv(CassetteModeEnvVar))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode == cassette.ModeOff {
+		return nil
+	}
+	if rec, ok := recorders.Load(t); ok {
+		return rec.(*cassette.Recorder)
+	}
+
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	rec, err := cassette.New(path, mode, cassetteOptions())
+	if err != nil {
+		t.Fatalf("%v; record it with %s=%s", err, CassetteModeEnvVar, cassette.ModeRecord)
+	}
+	recorders.Store(t, rec)
+
+	t.Cleanup(func() {
+		recorders.Delete(t)
+		if t.Failed() {
+			return
+		}
+		if err := rec.Save(); err != nil {
+			t.Errorf("saving cassette: %v", err)
+		}
+		for _, req := range rec.Unused() {
+			t.Errorf("cassette %s: recorded interaction not replayed: %s", path, req)
+		}
+	})
+	return rec
+}
@@ -0,0 +1,34 @@
+package acctest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-devops/internal/cassette"
+)
+
+// TestCassettesAreSanitized makes sure the committed cassettes hold no
+// personal data or credentials beyond the seed fixtures.
+func TestCassettesAreSanitized(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "provider", "*", "testdata", "cassettes", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no cassettes found; record them with make testacc-record")
+	}
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaks, err := cassette.Leaks(b, cassetteOptions())
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+		for _, leak := range leaks {
+			t.Errorf("%s: %s", path, leak)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// Package cassette records DOB API interactions to JSON files and replays
+// them, so provider tests can run without a backend.
+//
+// In record mode a Recorder passes requests through and keeps a sanitized
+// copy of every exchange: email addresses are replaced by stable
+// pseudonyms, secrets in JSON and form bodies are masked and request
+// headers are not kept at all. In replay mode it answers every request from
+// the cassette instead; a request that matches no unused recorded
+// interaction exactly (method, URL and body) fails.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Mode selects what a Recorder does.
+type Mode string
+
+const (
+	// ModeOff passes requests through untouched.
+	ModeOff Mode = ""
+	// ModeRecord passes requests through and records them.
+	ModeRecord Mode = "record"
+	// ModeReplay serves requests from a cassette.
+	ModeReplay Mode = "replay"
+)
+
+// ParseMode parses the value of a mode setting such as an environment
+// variable.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeOff, ModeRecord, ModeReplay:
+		return m, nil
+	}
+	return ModeOff, fmt.Errorf("invalid cassette mode %q, want %q or %q", s, ModeRecord, ModeReplay)
+}
+
+// Cassette is the on-disk format of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the sanitized part of a request that replay matches on.
+type Request struct {
+	Method string `json:"method"`
+	// URL is the request path and query; the endpoint's scheme and host
+	// are not recorded.
+	URL string `json:"url"`
+	Body
+}
+
+// Response is a sanitized recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body
+}
+
+// Body is a sanitized message body: JSON bodies are kept as JSON so
+// cassettes stay readable, anything else as text.
+type Body struct {
+	JSON json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+func (b Body) bytes() []byte {
+	if len(b.JSON) > 0 {
+		return b.JSON
+	}
+	return []byte(b.Text)
+}
+
+func (b Body) String() string {
+	return string(b.bytes())
+}
+
+// Options configures a Recorder.
+type Options struct {
+	// KeepEmails lists addresses that are fixture data rather than personal
+	// data and are recorded as is.
+	KeepEmails []string
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+type Recorder struct {
+	path string
+	mode Mode
+	base http.RoundTripper
+	s    *sanitizer
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New returns a Recorder for the cassette at path. In replay mode the
+// cassette must exist.
+func New(path string, mode Mode, opts Options) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, s: newSanitizer(opts.KeepEmails)}
+	if mode != ModeReplay {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	r.interactions = c.Interactions
+	r.used = make([]bool, len(c.Interactions))
+	return r, nil
+}
+
+// Mode returns the recorder's mode.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Wrap sets base as the transport requests are passed to and returns r.
+// It has the signature client.WithTransportWrapper expects.
+func (r *Recorder) Wrap(base http.RoundTripper) http.RoundTripper {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.base = base
+	return r
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := Request{
+		Method: req.Method,
+		URL:    r.s.text(req.URL.RequestURI()),
+		Body:   r.s.body(req.Header.Get("Content-Type"), body),
+	}
+
+	switch r.mode {
+	case ModeReplay:
+		return r.replay(req, recorded, body)
+	case ModeRecord:
+		return r.record(req, recorded)
+	default:
+		return r.transport().RoundTrip(req)
+	}
+}
+
+func (r *Recorder) transport() http.RoundTripper {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.base == nil {
+		return http.DefaultTransport
+	}
+	return r.base
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	res, err := r.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := res.Header.Clone()
+	for _, h := range []string{"Date", "Set-Cookie", "Content-Length"} {
+		header.Del(h)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       r.s.body(res.Header.Get("Content-Type"), body),
+		},
+	})
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.s.learn(body)
+
+	// Terraform may send independent requests concurrently, so the first
+	// unused matching interaction wins rather than the next one in line.
+	for i, in := range r.interactions {
+		if r.used[i] || !in.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		// Emails sent in the clear during this replay come back the same
+		// way, so Terraform sees the values it configured.
+		resBody := r.s.restore(in.Response.Body.bytes())
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Content-Length", strconv.Itoa(len(resBody)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(resBody)),
+			ContentLength: int64(len(resBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s: no recorded interaction matches %s", r.path, recorded)
+}
+
+func (r Request) String() string {
+	if b := r.Body.String(); b != "" {
+		return r.Method + " " + r.URL + " " + b
+	}
+	return r.Method + " " + r.URL
+}
+
+// matches reports whether o is the same request as r, comparing JSON
+// bodies semantically.
+func (r Request) matches(o Request) bool {
+	if r.Method != o.Method || r.URL != o.URL || r.Text != o.Text {
+		return false
+	}
+	if len(r.JSON) == 0 || len(o.JSON) == 0 {
+		return len(r.JSON) == len(o.JSON)
+	}
+	var a, b any
+	if json.Unmarshal(r.JSON, &a) != nil || json.Unmarshal(o.JSON, &b) != nil {
+		return bytes.Equal(r.JSON, o.JSON)
+	}
+	ab, _ := json.Marshal(a)
+	bb, _ := json.Marshal(b)
+	return bytes.Equal(ab, bb)
+}
+
+// Unused returns the recorded interactions a replay did not consume. It
+// returns nothing outside replay mode.
+func (r *Recorder) Unused() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode != ModeReplay {
+		return nil
+	}
+	var unused []Request
+	for i, in := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, in.Request)
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing outside record mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	c := Cassette{Interactions: append([]Interaction{}, r.interactions...)}
+	r.mu.Unlock()
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
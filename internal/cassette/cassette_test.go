@@ -0,0 +1,197 @@
+package cassette_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-devops/internal/cassette"
+	"terraform-provider-devops/internal/dobserver"
+	"terraform-provider-devops/internal/provider/client"
+)
+
+func newClient(t *testing.T, endpoint string, rec *cassette.Recorder) *client.Client {
+	t.Helper()
+	c, err := client.NewClient(&endpoint, client.WithRetry(1, 0), client.WithTransportWrapper(rec.Wrap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	ctx := context.Background()
+
+	srv, err := dobserver.New(dobserver.Options{Seed: &dobserver.Snapshot{
+		Engineers: []dobserver.Engineer{{ID: "AAAAA", Name: "Fixture", Email: "fixture@liatrio.com"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	opts := cassette.Options{KeepEmails: []string{"fixture@liatrio.com"}}
+	rec, err := cassette.New(path, cassette.ModeRecord, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(t, ts.URL, rec)
+	created, err := c.CreateEngineer(ctx, client.Engineer{Name: "Ann", Email: "ann@liatrio.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetEngineers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("recording reports unused interactions: %v", unused)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "ann@liatrio.com") {
+		t.Errorf("cassette leaks email:\n%s", b)
+	}
+	if !strings.Contains(string(b), "fixture@liatrio.com") {
+		t.Errorf("cassette scrubbed kept email:\n%s", b)
+	}
+
+	// Replay needs no server at all.
+	rec, err = cassette.New(path, cassette.ModeReplay, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = newClient(t, "http://dob.invalid", rec)
+	replayed, err := c.CreateEngineer(ctx, client.Engineer{Name: "Ann", Email: "ann@liatrio.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *replayed != *created {
+		t.Errorf("replayed %+v, want %+v", replayed, created)
+	}
+	engineers, err := c.GetEngineers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(engineers) != 2 || engineers[0].Email != "fixture@liatrio.com" || engineers[1].Email != "ann@liatrio.com" {
+		t.Errorf("replayed engineers = %+v", engineers)
+	}
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("unused interactions: %v", unused)
+	}
+}
+
+func TestReplayIsStrict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.json")
+	err := os.WriteFile(path, []byte(`{"interactions": [
+		{"request": {"method": "POST", "url": "/engineers", "body": {"name": "Ann", "email": "x", "id": ""}},
+		 "response": {"status_code": 201, "body": {"id": "AAAAA", "name": "Ann", "email": "x"}}}
+	]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := cassette.New(path, cassette.ModeReplay, cassette.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(t, "http://dob.invalid", rec)
+
+	_, err = c.CreateEngineer(context.Background(), client.Engineer{Name: "Bob", Email: "x"})
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction matches POST /engineers") {
+		t.Fatalf("err = %v, want no match", err)
+	}
+	if unused := rec.Unused(); len(unused) != 1 {
+		t.Errorf("unused = %v, want the POST", unused)
+	}
+
+	// Key order does not matter, but the interaction is used up afterwards.
+	if _, err := c.CreateEngineer(context.Background(), client.Engineer{Name: "Ann", Email: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateEngineer(context.Background(), client.Engineer{Name: "Ann", Email: "x"}); err == nil {
+		t.Fatal("want error replaying a used interaction twice")
+	}
+}
+
+func TestRecordMasksSecrets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "s3cr3t", "expires_in": 3600}`))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "test.json")
+	rec, err := cassette.New(path, cassette.ModeRecord, cassette.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := &http.Client{Transport: rec.Wrap(http.DefaultTransport)}
+	res, err := hc.PostForm(ts.URL+"/token", map[string][]string{"grant_type": {"client_credentials"}, "client_secret": {"hunter2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"s3cr3t", "hunter2"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette leaks %q:\n%s", secret, b)
+		}
+	}
+	if !strings.Contains(string(b), "grant_type=client_credentials") {
+		t.Errorf("cassette lost form fields:\n%s", b)
+	}
+	if leaks, err := cassette.Leaks(b, cassette.Options{}); err != nil || len(leaks) != 0 {
+		t.Errorf("Leaks() = %v, %v for a recorded cassette", leaks, err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"", "record", "replay"} {
+		if _, err := cassette.ParseMode(s); err != nil {
+			t.Errorf("ParseMode(%q): %v", s, err)
+		}
+	}
+	if _, err := cassette.ParseMode("rewind"); err == nil {
+		t.Error("ParseMode(rewind): want error")
+	}
+}
+
+func TestLeaks(t *testing.T) {
+	opts := cassette.Options{KeepEmails: []string{"fixture@liatrio.com"}}
+	clean := `{"interactions":[{"request":{"method":"POST","url":"/token","text":"client_secret=%2A%2A%2A&grant_type=client_credentials"},
+		"response":{"status_code":200,"body":{"access_token":"***","email":"user-0123abcd@example.com","owner":"fixture@liatrio.com"}}}]}`
+	if leaks, err := cassette.Leaks([]byte(clean), opts); err != nil || len(leaks) != 0 {
+		t.Errorf("Leaks(clean) = %v, %v", leaks, err)
+	}
+
+	leaky := `{"interactions":[{"request":{"method":"GET","url":"/engineers?email=ann@liatrio.com","text":"password=hunter2"},
+		"response":{"status_code":200,"header":{"Set-Cookie":["session=abc"]},"body":{"token":"abc","note":"Bearer abc.def"}}}]}`
+	leaks, err := cassette.Leaks([]byte(leaky), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(leaks, "\n")
+	for _, want := range []string{"email ann@liatrio.com", "field password", "header Set-Cookie", "field token", "Bearer abc.def"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Leaks() = %q, want it to report %q", leaks, want)
+		}
+	}
+}
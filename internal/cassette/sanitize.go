@@ -0,0 +1,201 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"mime"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// mask replaces secret values in cassettes.
+const mask = "***"
+
+// secretFields are the lower-cased JSON and form fields whose values are
+// masked.
+var secretFields = map[string]bool{
+	"password":      true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// sanitizer scrubs personal data and secrets from recorded traffic.
+type sanitizer struct {
+	keep map[string]bool
+	// seen maps pseudonyms to the addresses replayed requests sent.
+	seen map[string]string
+}
+
+func newSanitizer(keepEmails []string) *sanitizer {
+	s := &sanitizer{keep: map[string]bool{}, seen: map[string]string{}}
+	for _, e := range keepEmails {
+		s.keep[strings.ToLower(e)] = true
+	}
+	return s
+}
+
+// email returns the pseudonym recorded for addr. Pseudonyms are stable, so
+// the same address always matches across requests and recordings.
+func (s *sanitizer) email(addr string) string {
+	if s.keep[strings.ToLower(addr)] {
+		return addr
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(addr)))
+	return "user-" + hex.EncodeToString(sum[:4]) + "@example.com"
+}
+
+// text replaces every email address in v by its pseudonym.
+func (s *sanitizer) text(v string) string {
+	return emailPattern.ReplaceAllStringFunc(v, s.email)
+}
+
+// body sanitizes a message body of the given content type.
+func (s *sanitizer) body(contentType string, b []byte) Body {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return Body{}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		if out, err := json.Marshal(s.value(v)); err == nil {
+			return Body{JSON: out}
+		}
+	}
+
+	if mt, _, _ := mime.ParseMediaType(contentType); mt == "application/x-www-form-urlencoded" {
+		if form, err := url.ParseQuery(string(b)); err == nil {
+			for k, vs := range form {
+				for i := range vs {
+					if secretFields[strings.ToLower(k)] {
+						vs[i] = mask
+					} else {
+						vs[i] = s.text(vs[i])
+					}
+				}
+			}
+			return Body{Text: form.Encode()}
+		}
+	}
+	return Body{Text: s.text(string(b))}
+}
+
+// value sanitizes a decoded JSON value.
+func (s *sanitizer) value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			if secretFields[strings.ToLower(k)] {
+				v[k] = mask
+				continue
+			}
+			v[k] = s.value(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = s.value(item)
+		}
+		return v
+	case string:
+		return s.text(v)
+	default:
+		return v
+	}
+}
+
+// learn remembers the addresses in a request body sent in the clear, so
+// restore can map their pseudonyms back.
+func (s *sanitizer) learn(b []byte) {
+	for _, addr := range emailPattern.FindAllString(string(b), -1) {
+		if p := s.email(addr); p != addr {
+			s.seen[p] = addr
+		}
+	}
+}
+
+// restore replaces the pseudonyms of learned addresses in a replayed
+// response body by the addresses themselves.
+func (s *sanitizer) restore(b []byte) []byte {
+	if len(s.seen) == 0 {
+		return b
+	}
+	pairs := make([]string, 0, 2*len(s.seen))
+	for p, addr := range s.seen {
+		pairs = append(pairs, p, addr)
+	}
+	return []byte(strings.NewReplacer(pairs...).Replace(string(b)))
+}
+
+// pseudonymPattern matches the addresses email hands out.
+var pseudonymPattern = regexp.MustCompile(`^user-[0-9a-f]{8}@example\.com$`)
+
+// bearerPattern matches bearer tokens in any text.
+var bearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+`)
+
+// formFieldPattern matches the fields of form bodies and query strings.
+var formFieldPattern = regexp.MustCompile(`([A-Za-z_]+)=([^&\s]*)`)
+
+// credentialHeaders are the lower-cased headers that carry credentials.
+var credentialHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+}
+
+// Leaks returns the personal data and secrets found in the cassette file
+// contents b: email addresses that are neither in opts.KeepEmails nor
+// pseudonyms, secret fields that are not masked and credentials. A
+// cassette written by a Recorder has none.
+func Leaks(b []byte, opts Options) ([]string, error) {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	s := newSanitizer(opts.KeepEmails)
+	var leaks []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, item := range v {
+				switch key := strings.ToLower(k); {
+				case credentialHeaders[key]:
+					leaks = append(leaks, "header "+k)
+				case secretFields[key] && item != mask:
+					leaks = append(leaks, "field "+k)
+				default:
+					walk(item)
+				}
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		case string:
+			for _, addr := range emailPattern.FindAllString(v, -1) {
+				if !s.keep[strings.ToLower(addr)] && !pseudonymPattern.MatchString(addr) {
+					leaks = append(leaks, "email "+addr)
+				}
+			}
+			leaks = append(leaks, bearerPattern.FindAllString(v, -1)...)
+			for _, m := range formFieldPattern.FindAllStringSubmatch(v, -1) {
+				if value, err := url.QueryUnescape(m[2]); secretFields[strings.ToLower(m[1])] && (err != nil || value != mask) {
+					leaks = append(leaks, "field "+m[1])
+				}
+			}
+		}
+	}
+	walk(v)
+	return leaks, nil
+}
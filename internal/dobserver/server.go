@@ -0,0 +1,716 @@
+// Package dobserver is an in-memory implementation of the DOB API, for local
+// development and acceptance tests. It serves the routes the provider's
+// client uses and enforces the API's referential checks: groups may only
+// reference existing engineers and groups, and referenced objects cannot be
+// deleted.
+//
+// Responses carry an ETag; GETs honour If-None-Match and writes If-Match.
+//
+// GET /meta lists the API versions, each served under its own prefix such
+// as /v2. The unversioned routes behave like v1. Only v2 keeps group
+// descriptions.
+//
+// A Server is an http.Handler, so tests can run it with httptest.NewServer.
+package dobserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"terraform-provider-devops/internal/provider/client"
+)
+
+// Options configures a Server.
+type Options struct {
+	// DataFile, if set, is where the server persists its state after every
+	// change. An existing file is loaded on start and wins over Seed.
+	DataFile string
+	// Seed is the initial state when there is no data file to load.
+	Seed *Snapshot
+	// PageSize, if positive, makes list endpoints return pages of at most
+	// that many items in an {"items", "next"} envelope instead of a bare
+	// array.
+	PageSize int
+	// APIVersions restricts the versions the server offers, for example to
+	// test version negotiation. Nil offers all of them.
+	APIVersions []string
+	// Legacy makes the server predate versioning: it serves neither /meta
+	// nor versioned routes.
+	Legacy bool
+}
+
+// apiVersions are the API versions the server implements, oldest first.
+var apiVersions = []client.APIVersion{
+	{Version: "v1"},
+	{Version: "v2", Capabilities: []string{client.CapabilityGroupDescriptions}},
+}
+
+// Server is an in-memory DOB API.
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+
+	mu   sync.Mutex
+	data *Snapshot
+}
+
+// New returns a Server initialised from opts.
+func New(opts Options) (*Server, error) {
+	data, err := loadOrSeed(opts.DataFile, opts.Seed)
+	if err != nil {
+		return nil, err
+	}
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &Server{opts: opts, mux: http.NewServeMux(), data: data}
+	s.routes(apiVersions[0], "")
+	if opts.Legacy {
+		return s, nil
+	}
+	for _, v := range s.offered() {
+		s.routes(v, "/"+v.Version)
+	}
+	s.mux.HandleFunc("GET /meta", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, client.Meta{Versions: s.offered()})
+	})
+	return s, nil
+}
+
+// offered returns the API versions the server offers.
+func (s *Server) offered() []client.APIVersion {
+	if s.opts.APIVersions == nil {
+		return apiVersions
+	}
+	var offered []client.APIVersion
+	for _, v := range apiVersions {
+		if slices.Contains(s.opts.APIVersions, v.Version) {
+			offered = append(offered, v)
+		}
+	}
+	return offered
+}
+
+// routes registers the routes of API version v under prefix.
+func (s *Server) routes(v client.APIVersion, prefix string) {
+	s.handle(v, client.RESTRoutes(prefix+"/engineers"), s.listEngineers, s.getEngineer, s.createEngineer, s.updateEngineer, s.patchEngineer, s.deleteEngineer)
+	s.handle(v, client.RESTRoutes(prefix+"/dev"), s.listGroups(devGroups), s.getGroup(devGroups), s.createGroup(devGroups), s.updateGroup(devGroups), s.patchGroup(devGroups), s.deleteGroup(devGroups))
+	s.handle(v, client.RESTRoutes(prefix+"/op"), s.listGroups(opsGroups), s.getGroup(opsGroups), s.createGroup(opsGroups), s.updateGroup(opsGroups), s.patchGroup(opsGroups), s.deleteGroup(opsGroups))
+	s.handle(v, client.DevOpsRoutes(prefix+"/devops"), s.listDevOps, s.getDevOps, s.createDevOps, s.updateDevOps, s.patchDevOps, s.deleteDevOps)
+}
+
+// Snapshot returns a copy of the server's current state.
+func (s *Server) Snapshot() *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.clone()
+}
+
+// ServeHTTP implements http.Handler. Every response carries an
+// X-Request-ID, echoed from the request when the caller sent one.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := r.Header.Get("X-Request-ID")
+	if id == "" {
+		var b [8]byte
+		_, _ = rand.Read(b[:])
+		id = hex.EncodeToString(b[:])
+	}
+	w.Header().Set("X-Request-ID", id)
+	s.mux.ServeHTTP(w, r)
+}
+
+// handlerFunc handles a request and returns the status and body to answer
+// with. A nil body sends no content.
+type handlerFunc func(r *http.Request) (int, any)
+
+// versionKey is the context key of the API version a request is made in.
+type versionKey struct{}
+
+// supports reports whether the API version of r has capability.
+func supports(r *http.Request, capability string) bool {
+	v, _ := r.Context().Value(versionKey{}).(client.APIVersion)
+	return slices.Contains(v.Capabilities, capability)
+}
+
+// present returns body as the API version of r shows it: versions without
+// group descriptions never return them.
+func present(r *http.Request, body any) any {
+	if body == nil || supports(r, client.CapabilityGroupDescriptions) {
+		return body
+	}
+	b, _ := json.Marshal(body)
+	var v any
+	_ = json.Unmarshal(b, &v)
+	return withoutKey(v, "description")
+}
+
+func withoutKey(v any, key string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		delete(v, key)
+		for k, e := range v {
+			v[k] = withoutKey(e, key)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = withoutKey(e, key)
+		}
+	}
+	return v
+}
+
+func (s *Server) handle(v client.APIVersion, routes client.Routes, list, get, create, update, patch, del handlerFunc) {
+	for _, route := range []struct {
+		method, path string
+		h            handlerFunc
+	}{
+		{http.MethodGet, routes.List, list},
+		{http.MethodGet, routes.Get, get},
+		{http.MethodPost, routes.Create, create},
+		{http.MethodPut, routes.Update, update},
+		{http.MethodPatch, routes.Patch, patch},
+		{http.MethodDelete, routes.Delete, del},
+	} {
+		h := route.h
+		s.mux.HandleFunc(route.method+" "+route.path, func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(context.WithValue(r.Context(), versionKey{}, v))
+			s.mu.Lock()
+			status, body := s.checkIfMatch(r, get)
+			if status == 0 {
+				status, body = h(r)
+			}
+			body = present(r, body)
+			if status < 300 && r.Method != http.MethodGet && s.opts.DataFile != "" {
+				if err := s.data.save(s.opts.DataFile); err != nil {
+					status, body = errorf(http.StatusInternalServerError, "persisting state: %v", err)
+				}
+			}
+			s.mu.Unlock()
+
+			if status < 300 && body != nil {
+				etag := etagOf(body)
+				w.Header().Set("ETag", etag)
+				if r.Method == http.MethodGet && r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+			writeJSON(w, status, body)
+		})
+	}
+}
+
+// checkIfMatch answers 412 to a write whose If-Match header does not match
+// the current ETag of the object, as served by get. It returns a zero
+// status when the write may proceed.
+func (s *Server) checkIfMatch(r *http.Request, get handlerFunc) (int, any) {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == "*" || r.PathValue("id") == "" {
+		return 0, nil
+	}
+	status, current := get(r)
+	if status != http.StatusOK {
+		// Let the handler report the missing object.
+		return 0, nil
+	}
+	if etagOf(present(r, current)) != ifMatch {
+		return errorf(http.StatusPreconditionFailed, "%s was modified since version %s", r.URL.Path, ifMatch)
+	}
+	return 0, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// etagOf returns a strong entity tag for a response body.
+func etagOf(body any) string {
+	b, _ := json.Marshal(body)
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// errorf returns an error response in the API's error payload format.
+func errorf(status int, format string, args ...any) (int, any) {
+	return status, client.ErrorPayload{
+		Message: fmt.Sprintf(format, args...),
+		Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
+	}
+}
+
+// validationError collects field errors into a 422 response.
+type validationError []client.FieldError
+
+func (v *validationError) add(field, format string, args ...any) {
+	*v = append(*v, client.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v validationError) response() (int, any) {
+	return http.StatusUnprocessableEntity, client.ErrorPayload{
+		Message: "validation failed",
+		Code:    "validation_failed",
+		Errors:  v,
+	}
+}
+
+// decode reads the JSON request body into v.
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// paginate returns items as is, or the page selected by the request's
+// cursor when the server is configured with a page size.
+func paginate[T any](s *Server, r *http.Request, items []T) (int, any) {
+	if s.opts.PageSize <= 0 {
+		return http.StatusOK, items
+	}
+	offset := 0
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 0 || n > len(items) {
+			return errorf(http.StatusBadRequest, "invalid cursor %q", cursor)
+		}
+		offset = n
+	}
+	end := min(offset+s.opts.PageSize, len(items))
+	p := struct {
+		Items []T    `json:"items"`
+		Next  string `json:"next,omitempty"`
+	}{Items: items[offset:end]}
+	if end < len(items) {
+		p.Next = strconv.Itoa(end)
+	}
+	return http.StatusOK, p
+}
+
+// Engineers.
+
+func (s *Server) listEngineers(r *http.Request) (int, any) {
+	items := make([]client.Engineer, 0, len(s.data.Engineers))
+	for _, e := range s.data.Engineers {
+		items = append(items, client.Engineer(e))
+	}
+	return paginate(s, r, items)
+}
+
+func (s *Server) getEngineer(r *http.Request) (int, any) {
+	i := index(s.data.Engineers, r.PathValue("id"), engineerID)
+	if i < 0 {
+		return errorf(http.StatusNotFound, "engineer %s not found", r.PathValue("id"))
+	}
+	return http.StatusOK, client.Engineer(s.data.Engineers[i])
+}
+
+func (s *Server) createEngineer(r *http.Request) (int, any) {
+	var in client.Engineer
+	if err := decode(r, &in); err != nil {
+		return errorf(http.StatusBadRequest, "%v", err)
+	}
+	if verr := validateEngineer(in.Name, in.Email); verr != nil {
+		return verr.response()
+	}
+	e := Engineer{
+		ID:    newID(func(id string) bool { return index(s.data.Engineers, id, engineerID) >= 0 }),
+		Name:  in.Name,
+		Email: in.Email,
+	}
+	s.data.Engineers = append(s.data.Engineers, e)
+	return http.StatusCreated, client.Engineer(e)
+}
+
+func (s *Server) updateEngineer(r *http.Request) (int, any) {
+	var in client.Engineer
+	if err := decode(r, &in); err != nil {
+		return errorf(http.StatusBadRequest, "%v", err)
+	}
+	return s.setEngineer(r.PathValue("id"), &in.Name, &in.Email)
+}
+
+func (s *Server) patchEngineer(r *http.Request) (int, any) {
+	var in struct {
+		Name  *string `json:"name"`
+		Email *string `json:"email"`
+	}
+	if err := decode(r, &in); err != nil {
+		return errorf(http.StatusBadRequest, "%v", err)
+	}
+	return s.setEngineer(r.PathValue("id"), in.Name, in.Email)
+}
+
+// setEngineer updates the fields of an engineer that are not nil.
+func (s *Server) setEngineer(id string, name, email *string) (int, any) {
+	i := index(s.data.Engineers, id, engineerID)
+	if i < 0 {
+		return errorf(http.StatusNotFound, "engineer %s not found", id)
+	}
+	e := s.data.Engineers[i]
+	if name != nil {
+		e.Name = *name
+	}
+	if email != nil {
+		e.Email = *email
+	}
+	if verr := validateEngineer(e.Name, e.Email); verr != nil {
+		return verr.response()
+	}
+	s.data.Engineers[i] = e
+	return http.StatusOK, client.Engineer(e)
+}
+
+func (s *Server) deleteEngineer(r *http.Request) (int, any) {
+	id := r.PathValue("id")
+	i := index(s.data.Engineers, id, engineerID)
+	if i < 0 {
+		return errorf(http.StatusNotFound, "engineer %s not found", id)
+	}
+	for _, groups := range []struct {
+		kind   string
+		groups []Group
+	}{{"dev", s.data.Devs}, {"ops", s.data.Ops}} {
+		for _, g := range groups.groups {
+			if slices.Contains(g.Engineers, id) {
+				return errorf(http.StatusConflict, "engineer %s is a member of %s group %s", id, groups.kind, g.ID)
+			}
+		}
+	}
+	s.data.Engineers = slices.Delete(s.data.Engineers, i, i+1)
+	return http.StatusNoContent, nil
+}
+
+func validateEngineer(name, email string) validationError {
+	var verr validationError
+	if strings.TrimSpace(name) == "" {
+		verr.add("name", "must not be empty")
+	}
+	if !strings.Contains(email, "@") {
+		verr.add("email", "must be an email address")
+	}
+	return verr
+}
+
+// Dev and ops groups.
+
+// groupKind selects the dev or ops groups of a snapshot.
+type groupKind struct {
+	name   string
+	groups func(*Snapshot) *[]Group
+}
+
+var (
+	devGroups = groupKind{"dev", func(s *Snapshot) *[]Group { return &s.Devs }}
+	opsGroups = groupKind{"ops", func(s *Snapshot) *[]Group { return &s.Ops }}
+)
+
+// groupBody is the request body of group writes; members are referenced
+// by ID.
+type groupBody struct {
+	Name        *string            `json:"name"`
+	Description *string            `json:"description"`
+	Engineers   *[]client.Engineer `json:"engineers"`
+}
+
+// decodeGroup reads a group write, dropping the description in API
+// versions that do not keep it.
+func decodeGroup(r *http.Request) (groupBody, error) {
+	var in groupBody
+	err := decode(r, &in)
+	if !supports(r, client.CapabilityGroupDescriptions) {
+		in.Description = nil
+	}
+	return in, err
+}
+
+// expandGroup resolves a group's members to full engineers.
+func (s *Server) expandGroup(g Group) client.Dev {
+	out := client.Dev{ID: g.ID, Name: g.Name, Description: g.Description, Engineers: []client.Engineer{}}
+	for _, id := range g.Engineers {
+		if i := index(s.data.Engineers, id, engineerID); i >= 0 {
+			out.Engineers = append(out.Engineers, client.Engineer(s.data.Engineers[i]))
+		}
+	}
+	return out
+}
+
+func (s *Server) listGroups(kind groupKind) handlerFunc {
+	return func(r *http.Request) (int, any) {
+		groups := *kind.groups(s.data)
+		items := make([]client.Dev, 0, len(groups))
+		for _, g := range groups {
+			items = append(items, s.expandGroup(g))
+		}
+		return paginate(s, r, items)
+	}
+}
+
+func (s *Server) getGroup(kind groupKind) handlerFunc {
+	return func(r *http.Request) (int, any) {
+		groups := *kind.groups(s.data)
+		i := index(groups, r.PathValue("id"), groupID)
+		if i < 0 {
+			return errorf(http.StatusNotFound, "%s group %s not found", kind.name, r.PathValue("id"))
+		}
+		return http.StatusOK, s.expandGroup(groups[i])
+	}
+}
+
+func (s *Server) createGroup(kind groupKind) handlerFunc {
+	return func(r *http.Request) (int, any) {
+		in, err := decodeGroup(r)
+		if err != nil {
+			return errorf(http.StatusBadRequest, "%v", err)
+		}
+		groups := kind.groups(s.data)
+		g := Group{ID: newID(func(id string) bool { return index(*groups, id, groupID) >= 0 })}
+		if verr := s.applyGroup(&g, in); verr != nil {
+			return verr.response()
+		}
+		*groups = append(*groups, g)
+		return http.StatusCreated, s.expandGroup(g)
+	}
+}
+
+func (s *Server) updateGroup(kind groupKind) handlerFunc {
+	return func(r *http.Request) (int, any) {
+		in, err := decodeGroup(r)
+		if err != nil {
+			return errorf(http.StatusBadRequest, "%v", err)
+		}
+		// A full update replaces the member list even if it is omitted.
+		if in.Engineers == nil {
+			in.Engineers = &[]client.Engineer{}
+		}
+		if in.Name == nil {
+			in.Name = new(string)
+		}
+		if in.Description == nil && supports(r, client.CapabilityGroupDescriptions) {
+			in.Description = new(string)
+		}
+		return s.setGroup(kind, r.PathValue("id"), in)
+	}
+}
+
+func (s *Server) patchGroup(kind groupKind) handlerFunc {
+	return func(r *http.Request) (int, any) {
+		in, err := decodeGroup(r)
+		if err != nil {
+			return errorf(http.StatusBadRequest, "%v", err)
+		}
+		return s.setGroup(kind, r.PathValue("id"), in)
+	}
+}
+
+func (s *Server) setGroup(kind groupKind, id string, in groupBody) (int, any) {
+	groups := *kind.groups(s.data)
+	i := index(groups, id, groupID)
+	if i < 0 {
+		return errorf(http.StatusNotFound, "%s group %s not found", kind.name, id)
+	}
+	g := Group{ID: groups[i].ID, Name: groups[i].Name, Description: groups[i].Description, Engineers: slices.Clone(groups[i].Engineers)}
+	if verr := s.applyGroup(&g, in); verr != nil {
+		return verr.response()
+	}
+	groups[i] = g
+	return http.StatusOK, s.expandGroup(g)
+}
+
+// applyGroup sets the fields of g present in in, checking that every
+// member exists.
+func (s *Server) applyGroup(g *Group, in groupBody) validationError {
+	var verr validationError
+	if in.Name != nil {
+		g.Name = *in.Name
+	}
+	if in.Description != nil {
+		g.Description = *in.Description
+	}
+	if strings.TrimSpace(g.Name) == "" {
+		verr.add("name", "must not be empty")
+	}
+	if in.Engineers != nil {
+		g.Engineers = make([]string, 0, len(*in.Engineers))
+		for _, e := range *in.Engineers {
+			if index(s.data.Engineers, e.ID, engineerID) < 0 {
+				verr.add("engineers", "engineer %q does not exist", e.ID)
+				continue
+			}
+			if !slices.Contains(g.Engineers, e.ID) {
+				g.Engineers = append(g.Engineers, e.ID)
+			}
+		}
+	}
+	return verr
+}
+
+func (s *Server) deleteGroup(kind groupKind) handlerFunc {
+	return func(r *http.Request) (int, any) {
+		id := r.PathValue("id")
+		groups := kind.groups(s.data)
+		i := index(*groups, id, groupID)
+		if i < 0 {
+			return errorf(http.StatusNotFound, "%s group %s not found", kind.name, id)
+		}
+		for _, d := range s.data.DevOps {
+			refs := d.Dev
+			if kind.name == opsGroups.name {
+				refs = d.Ops
+			}
+			if slices.Contains(refs, id) {
+				return errorf(http.StatusConflict, "%s group %s is part of devops group %s", kind.name, id, d.ID)
+			}
+		}
+		*groups = slices.Delete(*groups, i, i+1)
+		return http.StatusNoContent, nil
+	}
+}
+
+// DevOps groups.
+
+// devopsBody is the request body of devops writes; groups are referenced
+// by ID.
+type devopsBody struct {
+	Dev *[]client.Dev `json:"dev"`
+	Ops *[]client.Ops `json:"ops"`
+}
+
+func (s *Server) expandDevOps(d DevOpsGroup) client.DevOps {
+	out := client.DevOps{ID: d.ID, Dev: []client.Dev{}, Ops: []client.Ops{}}
+	for _, id := range d.Dev {
+		if i := index(s.data.Devs, id, groupID); i >= 0 {
+			out.Dev = append(out.Dev, s.expandGroup(s.data.Devs[i]))
+		}
+	}
+	for _, id := range d.Ops {
+		if i := index(s.data.Ops, id, groupID); i >= 0 {
+			out.Ops = append(out.Ops, client.Ops(s.expandGroup(s.data.Ops[i])))
+		}
+	}
+	return out
+}
+
+func (s *Server) listDevOps(r *http.Request) (int, any) {
+	items := make([]client.DevOps, 0, len(s.data.DevOps))
+	for _, d := range s.data.DevOps {
+		items = append(items, s.expandDevOps(d))
+	}
+	return paginate(s, r, items)
+}
+
+func (s *Server) getDevOps(r *http.Request) (int, any) {
+	i := index(s.data.DevOps, r.PathValue("id"), devopsID)
+	if i < 0 {
+		return errorf(http.StatusNotFound, "devops group %s not found", r.PathValue("id"))
+	}
+	return http.StatusOK, s.expandDevOps(s.data.DevOps[i])
+}
+
+func (s *Server) createDevOps(r *http.Request) (int, any) {
+	var in devopsBody
+	if err := decode(r, &in); err != nil {
+		return errorf(http.StatusBadRequest, "%v", err)
+	}
+	d := DevOpsGroup{
+		ID:  newID(func(id string) bool { return index(s.data.DevOps, id, devopsID) >= 0 }),
+		Dev: []string{},
+		Ops: []string{},
+	}
+	if verr := s.applyDevOps(&d, in); verr != nil {
+		return verr.response()
+	}
+	s.data.DevOps = append(s.data.DevOps, d)
+	return http.StatusCreated, s.expandDevOps(d)
+}
+
+func (s *Server) updateDevOps(r *http.Request) (int, any) {
+	var in devopsBody
+	if err := decode(r, &in); err != nil {
+		return errorf(http.StatusBadRequest, "%v", err)
+	}
+	if in.Dev == nil {
+		in.Dev = &[]client.Dev{}
+	}
+	if in.Ops == nil {
+		in.Ops = &[]client.Ops{}
+	}
+	return s.setDevOps(r.PathValue("id"), in)
+}
+
+func (s *Server) patchDevOps(r *http.Request) (int, any) {
+	var in devopsBody
+	if err := decode(r, &in); err != nil {
+		return errorf(http.StatusBadRequest, "%v", err)
+	}
+	return s.setDevOps(r.PathValue("id"), in)
+}
+
+func (s *Server) setDevOps(id string, in devopsBody) (int, any) {
+	i := index(s.data.DevOps, id, devopsID)
+	if i < 0 {
+		return errorf(http.StatusNotFound, "devops group %s not found", id)
+	}
+	d := DevOpsGroup{ID: id, Dev: slices.Clone(s.data.DevOps[i].Dev), Ops: slices.Clone(s.data.DevOps[i].Ops)}
+	if verr := s.applyDevOps(&d, in); verr != nil {
+		return verr.response()
+	}
+	s.data.DevOps[i] = d
+	return http.StatusOK, s.expandDevOps(d)
+}
+
+// applyDevOps sets the group references of d present in in, checking that
+// every referenced group exists.
+func (s *Server) applyDevOps(d *DevOpsGroup, in devopsBody) validationError {
+	var verr validationError
+	if in.Dev != nil {
+		d.Dev = make([]string, 0, len(*in.Dev))
+		for _, g := range *in.Dev {
+			if index(s.data.Devs, g.ID, groupID) < 0 {
+				verr.add("dev", "dev group %q does not exist", g.ID)
+				continue
+			}
+			if !slices.Contains(d.Dev, g.ID) {
+				d.Dev = append(d.Dev, g.ID)
+			}
+		}
+	}
+	if in.Ops != nil {
+		d.Ops = make([]string, 0, len(*in.Ops))
+		for _, g := range *in.Ops {
+			if index(s.data.Ops, g.ID, groupID) < 0 {
+				verr.add("ops", "ops group %q does not exist", g.ID)
+				continue
+			}
+			if !slices.Contains(d.Ops, g.ID) {
+				d.Ops = append(d.Ops, g.ID)
+			}
+		}
+	}
+	return verr
+}
+
+func (s *Server) deleteDevOps(r *http.Request) (int, any) {
+	id := r.PathValue("id")
+	i := index(s.data.DevOps, id, devopsID)
+	if i < 0 {
+		return errorf(http.StatusNotFound, "devops group %s not found", id)
+	}
+	s.data.DevOps = slices.Delete(s.data.DevOps, i, i+1)
+	return http.StatusNoContent, nil
+}
@@ -0,0 +1,405 @@
+package dobserver_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"terraform-provider-devops/api"
+	"terraform-provider-devops/internal/dobserver"
+	"terraform-provider-devops/internal/openapi"
+	"terraform-provider-devops/internal/provider/client"
+)
+
+func newServer(t *testing.T, opts dobserver.Options) *client.Client {
+	t.Helper()
+	srv, err := dobserver.New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	c, err := client.NewClient(&ts.URL, client.WithRetry(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestServerCRUD(t *testing.T) {
+	c := newServer(t, dobserver.Options{})
+	ctx := context.Background()
+
+	e, err := c.CreateEngineer(ctx, client.Engineer{Name: "Ann", Email: "ann@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.ID) != 5 {
+		t.Errorf("engineer ID = %q, want five characters", e.ID)
+	}
+
+	dev, err := c.CreateDev(ctx, client.Dev{Name: "Team", Engineers: []client.Engineer{{ID: e.ID}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dev.Engineers) != 1 || dev.Engineers[0].Email != "ann@example.com" {
+		t.Errorf("dev engineers = %+v, want Ann expanded", dev.Engineers)
+	}
+
+	ops, err := c.CreateOps(ctx, client.Ops{Name: "Ops", Engineers: []client.Engineer{{ID: e.ID}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	devops, err := c.CreateDevops(ctx, client.DevOps{Dev: []client.Dev{{ID: dev.ID}}, Ops: []client.Ops{{ID: ops.ID}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.GetDevOpsByID(ctx, devops.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Dev) != 1 || got.Dev[0].Name != "Team" || len(got.Ops) != 1 {
+		t.Errorf("devops = %+v, want expanded groups", got)
+	}
+
+	if _, err := c.UpdateEngineer(ctx, e.ID, client.Engineer{Name: "Anne", Email: "anne@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := c.GetEngineer(ctx, e.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Name != "Anne" {
+		t.Errorf("name = %q, want Anne", updated.Name)
+	}
+
+	for _, del := range []func() error{
+		func() error { return c.DeleteDevOps(ctx, devops.ID) },
+		func() error { return c.DeleteOps(ctx, ops.ID) },
+		func() error { return c.DeleteDev(ctx, dev.ID) },
+		func() error { return c.DeleteEngineer(ctx, e.ID) },
+	} {
+		if err := del(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.GetEngineer(ctx, e.ID); !client.IsNotFound(err) {
+		t.Errorf("GetEngineer after delete: err = %v, want not found", err)
+	}
+}
+
+func TestServerReferentialChecks(t *testing.T) {
+	c := newServer(t, dobserver.Options{})
+	ctx := context.Background()
+
+	_, err := c.CreateDev(ctx, client.Dev{Name: "Team", Engineers: []client.Engineer{{ID: "NOPE1"}}})
+	if !client.IsValidation(err) {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+	apiErr, _ := client.AsAPIError(err)
+	if fe := apiErr.FieldErrors(); len(fe) != 1 || fe[0].Field != "engineers" {
+		t.Errorf("field errors = %+v, want one on engineers", fe)
+	}
+	if apiErr.RequestID == "" {
+		t.Error("missing request ID")
+	}
+
+	e, err := c.CreateEngineer(ctx, client.Engineer{Name: "Ann", Email: "ann@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateDev(ctx, client.Dev{Name: "Team", Engineers: []client.Engineer{{ID: e.ID}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteEngineer(ctx, e.ID); !client.IsConflict(err) {
+		t.Errorf("deleting referenced engineer: err = %v, want conflict", err)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	seed := &dobserver.Snapshot{}
+	for _, id := range []string{"AAAAA", "BBBBB", "CCCCC"} {
+		seed.Engineers = append(seed.Engineers, dobserver.Engineer{ID: id, Name: id, Email: id + "@example.com"})
+	}
+	c := newServer(t, dobserver.Options{Seed: seed, PageSize: 2})
+
+	engineers, err := c.GetEngineers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(engineers) != 3 || engineers[2].ID != "CCCCC" {
+		t.Errorf("engineers = %+v, want all three", engineers)
+	}
+}
+
+func TestServerPersistence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "dob.json")
+	c := newServer(t, dobserver.Options{DataFile: file})
+	e, err := c.CreateEngineer(context.Background(), client.Engineer{Name: "Ann", Email: "ann@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := dobserver.LoadSnapshot(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.Engineers) != 1 || snap.Engineers[0].ID != e.ID {
+		t.Fatalf("persisted engineers = %+v, want %s", snap.Engineers, e.ID)
+	}
+
+	// A restarted server picks the state up again, ignoring the seed.
+	c = newServer(t, dobserver.Options{DataFile: file, Seed: &dobserver.Snapshot{}})
+	if _, err := c.GetEngineer(context.Background(), e.ID); err != nil {
+		t.Errorf("engineer lost across restart: %v", err)
+	}
+}
+
+func TestNewRejectsDanglingReferences(t *testing.T) {
+	_, err := dobserver.New(dobserver.Options{Seed: &dobserver.Snapshot{
+		Devs: []dobserver.Group{{ID: "AAAAA", Name: "Team", Engineers: []string{"NOPE1"}}},
+	}})
+	if err == nil {
+		t.Fatal("want error for dev group with unknown engineer")
+	}
+}
+
+func TestServerMethodNotAllowed(t *testing.T) {
+	srv, err := dobserver.New(dobserver.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/engineers/AAAAA", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want 405", rec.Code)
+	}
+}
+
+func TestServerIfMatch(t *testing.T) {
+	c := newServer(t, dobserver.Options{})
+	ctx := context.Background()
+
+	e, version, err := c.Engineers.CreateVersioned(ctx, client.Engineer{Name: "Ann", Email: "ann@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version == "" {
+		t.Fatal("create returned no version")
+	}
+	if _, got, err := c.Engineers.GetVersioned(ctx, e.ID); err != nil || got != version {
+		t.Fatalf("get version = %q, %v, want %q", got, err, version)
+	}
+
+	// A change made elsewhere invalidates the version.
+	if _, err := c.UpdateEngineer(ctx, e.ID, client.Engineer{Name: "Anne", Email: "ann@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Engineers.UpdateIfMatch(ctx, e.ID, client.Engineer{Name: "Bob", Email: "bob@example.com"}, version)
+	if !client.IsPreconditionFailed(err) {
+		t.Fatalf("stale update err = %v, want 412", err)
+	}
+	if err := c.Engineers.DeleteIfMatch(ctx, e.ID, version); !client.IsPreconditionFailed(err) {
+		t.Fatalf("stale delete err = %v, want 412", err)
+	}
+
+	_, version, err = c.Engineers.GetVersioned(ctx, e.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Engineers.UpdateIfMatch(ctx, e.ID, client.Engineer{Name: "Bob", Email: "bob@example.com"}, version); err != nil {
+		t.Fatalf("current update: %v", err)
+	}
+	_, version, err = c.Engineers.GetVersioned(ctx, e.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Engineers.DeleteIfMatch(ctx, e.ID, version); err != nil {
+		t.Fatalf("current delete: %v", err)
+	}
+}
+
+func TestServerAPIVersions(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name         string
+		opts         dobserver.Options
+		wantVersion  string
+		descriptions bool
+	}{
+		{name: "all versions", wantVersion: "v2", descriptions: true},
+		{name: "v1 only", opts: dobserver.Options{APIVersions: []string{"v1"}}, wantVersion: "v1"},
+		{name: "legacy", opts: dobserver.Options{Legacy: true}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, tt.opts)
+			if err := c.Negotiate(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.APIVersion(); got != tt.wantVersion {
+				t.Errorf("APIVersion() = %q, want %q", got, tt.wantVersion)
+			}
+			if got := c.Supports(client.CapabilityGroupDescriptions); got != tt.descriptions {
+				t.Errorf("Supports(group_descriptions) = %v, want %v", got, tt.descriptions)
+			}
+
+			dev, err := c.CreateDev(ctx, client.Dev{Name: "Team", Description: "Builds things"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := ""
+			if tt.descriptions {
+				want = "Builds things"
+			}
+			if got, err := c.GetDevByID(ctx, dev.ID); err != nil || got.Description != want {
+				t.Errorf("description = %q, %v, want %q", got.Description, err, want)
+			}
+		})
+	}
+}
+
+func TestServerDescriptionsPerVersion(t *testing.T) {
+	srv, err := dobserver.New(dobserver.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	ctx := context.Background()
+
+	v2, err := client.NewClient(&ts.URL, client.WithRetry(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v2.Negotiate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ops, err := v2.CreateOps(ctx, client.Ops{Name: "Ops", Description: "Keeps things running"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unversioned clients neither see nor erase the description.
+	legacy, err := client.NewClient(&ts.URL, client.WithRetry(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := legacy.GetOpsByID(ctx, ops.ID); err != nil || got.Description != "" {
+		t.Errorf("unversioned description = %q, %v, want none", got.Description, err)
+	}
+	if _, err := legacy.UpdateOps(ctx, ops.ID, client.Ops{Name: "Renamed", Description: "ignored"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := v2.GetOpsByID(ctx, ops.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Renamed" || got.Description != "Keeps things running" {
+		t.Errorf("v2 read = %+v, want renamed group with its description", got)
+	}
+}
+
+// versionPrefix matches the version segment that negotiated clients put
+// in front of the specified paths.
+var versionPrefix = regexp.MustCompile(`^/v[0-9]+/`)
+
+// specChecker fails t for every response that the API specification does
+// not allow for the request.
+type specChecker struct {
+	t    *testing.T
+	doc  *openapi.Document
+	base http.RoundTripper
+}
+
+func (sc *specChecker) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := sc.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	_, op, ok := sc.doc.Match(req.Method, versionPrefix.ReplaceAllString(req.URL.Path, "/"))
+	if !ok {
+		sc.t.Errorf("%s %s: no such operation in the specification", req.Method, req.URL.Path)
+		return res, nil
+	}
+	schema, ok := sc.doc.ResponseSchema(op, res.StatusCode)
+	switch {
+	case !ok:
+		sc.t.Errorf("%s: status %d is not specified", op.OperationID, res.StatusCode)
+	case schema == nil && len(body) > 0:
+		sc.t.Errorf("%s: status %d has a body, but none is specified", op.OperationID, res.StatusCode)
+	case schema != nil:
+		if err := sc.doc.ValidateJSON(schema, body); err != nil {
+			sc.t.Errorf("%s: status %d body %s: %v", op.OperationID, res.StatusCode, body, err)
+		}
+	}
+	return res, nil
+}
+
+func TestServerConformsToSpec(t *testing.T) {
+	doc, err := openapi.Load(api.OpenAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pageSize := range []int{0, 1} {
+		srv, err := dobserver.New(dobserver.Options{PageSize: pageSize})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := httptest.NewServer(srv)
+		defer ts.Close()
+		c, err := client.NewClient(&ts.URL, client.WithRetry(1, 0), client.WithTransportWrapper(func(base http.RoundTripper) http.RoundTripper {
+			return &specChecker{t: t, doc: doc, base: base}
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.Background()
+		if err := c.Negotiate(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		e, err := c.CreateEngineer(ctx, client.Engineer{Name: "Ann", Email: "ann@example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = c.CreateEngineer(ctx, client.Engineer{Name: "Bob", Email: "bob@example.com"})
+		_, _ = c.CreateEngineer(ctx, client.Engineer{Name: "", Email: "nobody"})
+		dev, err := c.CreateDev(ctx, client.Dev{Name: "Team", Description: "Builds things", Engineers: []client.Engineer{{ID: e.ID}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ops, err := c.CreateOps(ctx, client.Ops{Name: "Ops", Engineers: []client.Engineer{{ID: e.ID}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		devops, err := c.CreateDevops(ctx, client.DevOps{Dev: []client.Dev{{ID: dev.ID}}, Ops: []client.Ops{{ID: ops.ID}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = c.GetEngineers(ctx)
+		_, _ = c.GetDev(ctx)
+		_, _ = c.GetOps(ctx)
+		_, _ = c.GetDevOps(ctx)
+		_, _ = c.GetEngineer(ctx, e.ID)
+		_, _ = c.GetEngineer(ctx, "NOPE1")
+		_, _ = c.GetDevOpsByID(ctx, devops.ID)
+		_, _ = c.UpdateDev(ctx, dev.ID, client.Dev{Name: "Renamed", Engineers: []client.Engineer{{ID: e.ID}}})
+		_, _ = c.Engineers.UpdateIfMatch(ctx, e.ID, client.Engineer{Name: "Ann", Email: "ann@example.com"}, `"stale"`)
+		_ = c.DeleteEngineer(ctx, e.ID)
+		_ = c.DeleteDevOps(ctx, devops.ID)
+	}
+}
@@ -0,0 +1,174 @@
+package dobserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Snapshot is the complete state of a Server, as persisted to its data
+// file. Groups reference their members by ID.
+type Snapshot struct {
+	Engineers []Engineer    `json:"engineers"`
+	Devs      []Group       `json:"dev"`
+	Ops       []Group       `json:"ops"`
+	DevOps    []DevOpsGroup `json:"devops"`
+}
+
+// Engineer is a stored engineer.
+type Engineer struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Group is a stored dev or ops group.
+type Group struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Engineers   []string `json:"engineers"`
+}
+
+// DevOpsGroup is a stored devops group.
+type DevOpsGroup struct {
+	ID  string   `json:"id"`
+	Dev []string `json:"dev"`
+	Ops []string `json:"ops"`
+}
+
+// LoadSnapshot reads a snapshot from a JSON file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// loadOrSeed returns the snapshot stored at path, or a copy of seed if
+// path is empty or does not exist yet.
+func loadOrSeed(path string, seed *Snapshot) (*Snapshot, error) {
+	if path != "" {
+		s, err := LoadSnapshot(path)
+		if err == nil {
+			return s, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	if seed == nil {
+		return &Snapshot{}, nil
+	}
+	return seed.clone(), nil
+}
+
+// save writes s to path atomically.
+func (s *Snapshot) save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *Snapshot) clone() *Snapshot {
+	c := &Snapshot{
+		Engineers: slices.Clone(s.Engineers),
+		Devs:      make([]Group, len(s.Devs)),
+		Ops:       make([]Group, len(s.Ops)),
+		DevOps:    make([]DevOpsGroup, len(s.DevOps)),
+	}
+	for i, g := range s.Devs {
+		c.Devs[i] = Group{ID: g.ID, Name: g.Name, Engineers: slices.Clone(g.Engineers)}
+	}
+	for i, g := range s.Ops {
+		c.Ops[i] = Group{ID: g.ID, Name: g.Name, Engineers: slices.Clone(g.Engineers)}
+	}
+	for i, g := range s.DevOps {
+		c.DevOps[i] = DevOpsGroup{ID: g.ID, Dev: slices.Clone(g.Dev), Ops: slices.Clone(g.Ops)}
+	}
+	return c
+}
+
+// Validate checks that every reference in s points at an existing object.
+func (s *Snapshot) Validate() error {
+	var problems []string
+	for _, groups := range []struct {
+		kind   string
+		groups []Group
+	}{{"dev", s.Devs}, {"ops", s.Ops}} {
+		for _, g := range groups.groups {
+			for _, id := range g.Engineers {
+				if index(s.Engineers, id, engineerID) < 0 {
+					problems = append(problems, fmt.Sprintf("%s group %s references unknown engineer %s", groups.kind, g.ID, id))
+				}
+			}
+		}
+	}
+	for _, d := range s.DevOps {
+		for _, id := range d.Dev {
+			if index(s.Devs, id, groupID) < 0 {
+				problems = append(problems, fmt.Sprintf("devops group %s references unknown dev group %s", d.ID, id))
+			}
+		}
+		for _, id := range d.Ops {
+			if index(s.Ops, id, groupID) < 0 {
+				problems = append(problems, fmt.Sprintf("devops group %s references unknown ops group %s", d.ID, id))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid snapshot: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// idAlphabet matches the five character IDs the DOB API hands out.
+const idAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// newID returns a random ID for which taken reports false.
+func newID(taken func(string) bool) string {
+	for {
+		var b [5]byte
+		_, _ = rand.Read(b[:])
+		for i := range b {
+			b[i] = idAlphabet[int(b[i])%len(idAlphabet)]
+		}
+		if id := string(b[:]); !taken(id) {
+			return id
+		}
+	}
+}
+
+// index returns the position of the element with the given ID, or -1.
+func index[T any](items []T, id string, idOf func(T) string) int {
+	return slices.IndexFunc(items, func(item T) bool { return idOf(item) == id })
+}
+
+func engineerID(e Engineer) string  { return e.ID }
+func groupID(g Group) string        { return g.ID }
+func devopsID(g DevOpsGroup) string { return g.ID }
@@ -0,0 +1,229 @@
+// Package faultinject provides an http.RoundTripper that injects faults
+// into DOB API traffic, so tests can exercise the client's and resources'
+// error paths: slow responses, error statuses, truncated or corrupted
+// bodies and dropped connections.
+//
+// Plug it into a client with client.WithTransportWrapper:
+//
+//	ft := &faultinject.Transport{}
+//	ft.Script("POST", "/engineers", faultinject.Fault{StatusCode: 500})
+//	c, _ := client.NewClient(&url, client.WithTransportWrapper(ft.Wrap))
+package faultinject
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault describes what goes wrong with one request. The zero Fault passes
+// the request through untouched.
+type Fault struct {
+	// Latency delays the request before it is sent, or until its context
+	// is done.
+	Latency time.Duration
+
+	// Drop fails the request with a connection reset without sending it.
+	Drop bool
+
+	// StatusCode, if set, answers the request with this status and Body
+	// instead of sending it. With Forward the request is sent first and
+	// only its response is replaced, as when the API applies a change but
+	// fails to answer.
+	StatusCode int
+	Forward    bool
+
+	// Body replaces the response body when not nil.
+	Body []byte
+	// Truncate cuts the response body to this many bytes when positive.
+	Truncate int
+	// Corrupt garbles the response body so it is no longer valid JSON.
+	Corrupt bool
+	// ResetBody fails reading the response body with a connection reset
+	// after half of it has been delivered.
+	ResetBody bool
+}
+
+// Transport is an http.RoundTripper that applies Faults to requests before
+// handing them to Base. Scripted faults for a route win over Default.
+type Transport struct {
+	// Base sends the requests. It defaults to http.DefaultTransport.
+	Base http.RoundTripper
+
+	// Default applies to every request without a scripted fault.
+	Default Fault
+	// DropRate is the probability, between 0 and 1, that a request
+	// without a scripted fault is dropped.
+	DropRate float64
+
+	mu       sync.Mutex
+	scripts  map[string][]Fault
+	requests []string
+}
+
+// Wrap sets base as t's Base and returns t. It has the signature
+// client.WithTransportWrapper expects.
+func (t *Transport) Wrap(base http.RoundTripper) http.RoundTripper {
+	t.Base = base
+	return t
+}
+
+// Script queues faults for the next requests with the given method and
+// URL path, one fault per request. Once the queue is drained, requests to
+// the route fall back to Default.
+func (t *Transport) Script(method, path string, faults ...Fault) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.scripts == nil {
+		t.scripts = map[string][]Fault{}
+	}
+	key := method + " " + path
+	t.scripts[key] = append(t.scripts[key], faults...)
+}
+
+// Requests returns the "METHOD /path" of every request seen so far,
+// including dropped ones.
+func (t *Transport) Requests() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.requests...)
+}
+
+// next returns the fault for a request to key.
+func (t *Transport) next(key string) Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests = append(t.requests, key)
+	if queue := t.scripts[key]; len(queue) > 0 {
+		t.scripts[key] = queue[1:]
+		return queue[0]
+	}
+	f := t.Default
+	if t.DropRate > 0 && rand.Float64() < t.DropRate {
+		f.Drop = true
+	}
+	return f
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f := t.next(req.Method + " " + req.URL.Path)
+
+	if f.Latency > 0 {
+		if err := sleep(req.Context(), f.Latency); err != nil {
+			closeBody(req)
+			return nil, err
+		}
+	}
+	if f.Drop {
+		closeBody(req)
+		return nil, connReset("write")
+	}
+
+	var res *http.Response
+	if f.StatusCode != 0 && !f.Forward {
+		closeBody(req)
+		res = &http.Response{
+			Status:     fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode)),
+			StatusCode: f.StatusCode,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       http.NoBody,
+			Request:    req,
+		}
+	} else {
+		base := t.Base
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		var err error
+		res, err = base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		if f.StatusCode != 0 {
+			res.StatusCode = f.StatusCode
+			res.Status = fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode))
+		}
+	}
+
+	if f.Body == nil && f.Truncate <= 0 && !f.Corrupt && !f.ResetBody {
+		return res, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if f.Body != nil {
+		body = f.Body
+	}
+	if f.Truncate > 0 && f.Truncate < len(body) {
+		body = body[:f.Truncate]
+	}
+	if f.Corrupt {
+		body = corrupt(body)
+	}
+	res.ContentLength = int64(len(body))
+	res.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	if f.ResetBody {
+		res.Body = &resetReader{r: bytes.NewReader(body[:len(body)/2])}
+	} else {
+		res.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return res, nil
+}
+
+// corrupt returns body with its second half replaced by bytes that are not
+// valid JSON.
+func corrupt(body []byte) []byte {
+	out := append([]byte(nil), body[:len(body)/2]...)
+	return append(out, "\x00<garbled>"...)
+}
+
+// resetReader yields its data and then fails like a reset connection.
+type resetReader struct {
+	r io.Reader
+}
+
+func (r *resetReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err == io.EOF {
+		err = connReset("read")
+	}
+	return n, err
+}
+
+func (r *resetReader) Close() error { return nil }
+
+func connReset(op string) error {
+	return &net.OpError{Op: op, Net: "tcp", Err: syscall.ECONNRESET}
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,141 @@
+package faultinject_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"terraform-provider-devops/internal/dobserver"
+	"terraform-provider-devops/internal/faultinject"
+	"terraform-provider-devops/internal/provider/client"
+)
+
+// newClient returns a client talking to a fresh in-memory API through ft.
+func newClient(t *testing.T, ft *faultinject.Transport, seed *dobserver.Snapshot) (*client.Client, *dobserver.Server) {
+	t.Helper()
+	srv, err := dobserver.New(dobserver.Options{Seed: seed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	c, err := client.NewClient(&ts.URL,
+		client.WithRetry(3, time.Millisecond),
+		client.WithTransportWrapper(ft.Wrap),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, srv
+}
+
+var seed = &dobserver.Snapshot{
+	Engineers: []dobserver.Engineer{{ID: "AAAAA", Name: "Ann", Email: "ann@example.com"}},
+}
+
+func TestRetriesTransientFaults(t *testing.T) {
+	cases := map[string]faultinject.Fault{
+		"server error":      {StatusCode: http.StatusServiceUnavailable},
+		"dropped":           {Drop: true},
+		"reset during body": {ResetBody: true},
+	}
+	for name, fault := range cases {
+		t.Run(name, func(t *testing.T) {
+			ft := &faultinject.Transport{}
+			ft.Script(http.MethodGet, "/engineers/id/AAAAA", fault, fault)
+			c, _ := newClient(t, ft, seed)
+
+			e, err := c.GetEngineer(context.Background(), "AAAAA")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Name != "Ann" {
+				t.Errorf("name = %q, want Ann", e.Name)
+			}
+			if n := len(ft.Requests()); n != 3 {
+				t.Errorf("sent %d requests, want 3", n)
+			}
+		})
+	}
+}
+
+func TestGivesUpAfterMaxAttempts(t *testing.T) {
+	ft := &faultinject.Transport{Default: faultinject.Fault{Drop: true}}
+	c, _ := newClient(t, ft, seed)
+
+	_, err := c.GetEngineer(context.Background(), "AAAAA")
+	if !errors.Is(err, syscall.ECONNRESET) {
+		t.Fatalf("err = %v, want connection reset", err)
+	}
+	if n := len(ft.Requests()); n != 3 {
+		t.Errorf("sent %d requests, want 3", n)
+	}
+}
+
+func TestInvalidBodies(t *testing.T) {
+	cases := map[string]faultinject.Fault{
+		"truncated": {Truncate: 10},
+		"corrupted": {Corrupt: true},
+		"replaced":  {Body: []byte("<html>502 Bad Gateway</html>")},
+	}
+	for name, fault := range cases {
+		t.Run(name, func(t *testing.T) {
+			ft := &faultinject.Transport{Default: fault}
+			c, _ := newClient(t, ft, seed)
+
+			_, err := c.GetEngineer(context.Background(), "AAAAA")
+			if err == nil || !strings.Contains(err.Error(), "decoding response") {
+				t.Errorf("err = %v, want decoding error", err)
+			}
+		})
+	}
+}
+
+func TestServerErrorAfterApplyIsNotRetried(t *testing.T) {
+	ft := &faultinject.Transport{}
+	ft.Script(http.MethodPost, "/engineers", faultinject.Fault{StatusCode: http.StatusInternalServerError, Forward: true})
+	c, srv := newClient(t, ft, nil)
+
+	_, err := c.CreateEngineer(context.Background(), client.Engineer{Name: "Bob", Email: "bob@example.com"})
+	if !client.HasStatus(err, http.StatusInternalServerError) {
+		t.Fatalf("err = %v, want 500", err)
+	}
+	if n := len(ft.Requests()); n != 1 {
+		t.Errorf("sent %d requests, want 1: POST must not be retried", n)
+	}
+	// The API did apply the change; only its answer was lost.
+	if n := len(srv.Snapshot().Engineers); n != 1 {
+		t.Errorf("server has %d engineers, want 1", n)
+	}
+}
+
+func TestLatencyRespectsDeadline(t *testing.T) {
+	ft := &faultinject.Transport{Default: faultinject.Fault{Latency: time.Minute}}
+	c, _ := newClient(t, ft, seed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.GetEngineer(ctx, "AAAAA")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+}
+
+func TestDropRate(t *testing.T) {
+	ft := &faultinject.Transport{DropRate: 1}
+	ft.Script(http.MethodGet, "/engineers/id/AAAAA", faultinject.Fault{})
+	c, _ := newClient(t, ft, seed)
+
+	// The scripted pass-through wins over the drop rate.
+	if _, err := c.GetEngineer(context.Background(), "AAAAA"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetEngineer(context.Background(), "AAAAA"); err == nil {
+		t.Fatal("want error once the script is drained")
+	}
+}
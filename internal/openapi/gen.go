@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// initialisms are property name parts spelled in capitals in Go names.
+var initialisms = map[string]string{
+	"api":  "API",
+	"http": "HTTP",
+	"id":   "ID",
+	"url":  "URL",
+}
+
+// GenerateModels returns Go source declaring a struct for every component
+// schema of d in package pkg. source names the document in the generated
+// file's header.
+func (d *Document) GenerateModels(pkg, source string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by dob-modelgen from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package %s\n", pkg)
+
+	for _, ns := range d.Components.Schemas {
+		s := ns.Schema
+		if s.Type != "object" {
+			return nil, fmt.Errorf("schema %s: only object schemas are supported, got %q", ns.Name, s.Type)
+		}
+		b.WriteString("\n")
+		writeComment(&b, "", typeDoc(ns.Name, s.Description))
+		fmt.Fprintf(&b, "type %s struct {\n", ns.Name)
+		for i, p := range s.Properties {
+			typ, err := d.goType(p.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("schema %s: property %s: %w", ns.Name, p.Name, err)
+			}
+			if p.Schema.Description != "" {
+				if i > 0 {
+					b.WriteString("\n")
+				}
+				writeComment(&b, "\t", p.Schema.Description)
+			}
+			tag := p.Name
+			if !slices.Contains(s.Required, p.Name) {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", goName(p.Name), typ, tag)
+		}
+		b.WriteString("}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated models: %w", err)
+	}
+	return src, nil
+}
+
+func (d *Document) goType(s *Schema) (string, error) {
+	if name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/"); ok {
+		if d.Components.Schemas.Get(name) == nil {
+			return "", fmt.Errorf("unknown schema %q", name)
+		}
+		return name, nil
+	}
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		elem, err := d.goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	}
+	return "", fmt.Errorf("unsupported schema type %q; declare objects as components", s.Type)
+}
+
+// goName converts a JSON property name such as "team_id" to a Go field
+// name such as "TeamID".
+func goName(property string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(property, func(r rune) bool { return r == '_' || r == '-' }) {
+		if s, ok := initialisms[strings.ToLower(part)]; ok {
+			b.WriteString(s)
+			continue
+		}
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// typeDoc turns a schema description such as "An engineer." into a doc
+// comment for the type name.
+func typeDoc(name, description string) string {
+	if description == "" {
+		return name + " is generated from the " + name + " schema."
+	}
+	r := []rune(description)
+	if len(r) > 1 && !unicode.IsUpper(r[1]) {
+		r[0] = unicode.ToLower(r[0])
+	}
+	return name + " is " + string(r)
+}
+
+// writeComment writes text as a line comment wrapped at 76 columns.
+func writeComment(b *bytes.Buffer, indent, text string) {
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(indent)+len(line)+len(word)+4 > 76 {
+			fmt.Fprintf(b, "%s// %s\n", indent, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
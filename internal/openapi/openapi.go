@@ -0,0 +1,335 @@
+// Package openapi reads the subset of OpenAPI 3 used by the DOB API's
+// specification in api/openapi.json. It matches requests to the
+// specification's operations, validates JSON values against its schemas
+// and generates the client's model structs from them.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Components holds the reusable parts of a document.
+type Components struct {
+	Schemas    Schemas               `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+	Responses  map[string]*Response  `json:"responses"`
+}
+
+// PathItem holds the operations on one path template.
+type PathItem struct {
+	Parameters []*Parameter `json:"parameters"`
+	Get        *Operation   `json:"get"`
+	Post       *Operation   `json:"post"`
+	Put        *Operation   `json:"put"`
+	Patch      *Operation   `json:"patch"`
+	Delete     *Operation   `json:"delete"`
+}
+
+// Operations returns the item's operations by HTTP method.
+func (p *PathItem) Operations() map[string]*Operation {
+	ops := map[string]*Operation{}
+	for method, op := range map[string]*Operation{
+		http.MethodGet:    p.Get,
+		http.MethodPost:   p.Post,
+		http.MethodPut:    p.Put,
+		http.MethodPatch:  p.Patch,
+		http.MethodDelete: p.Delete,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+// Operation is a single API operation.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path, query or header parameter.
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes an operation's request body.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes one response of an operation.
+type Response struct {
+	Ref     string                `json:"$ref"`
+	Content map[string]*MediaType `json:"content"`
+}
+
+// MediaType holds the schema of a body of one content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema as used by OpenAPI 3.0.
+type Schema struct {
+	Ref         string    `json:"$ref"`
+	Description string    `json:"description"`
+	Type        string    `json:"type"`
+	Nullable    bool      `json:"nullable"`
+	Required    []string  `json:"required"`
+	Properties  Schemas   `json:"properties"`
+	Items       *Schema   `json:"items"`
+	OneOf       []*Schema `json:"oneOf"`
+}
+
+// NamedSchema is a schema under a name, such as a property or a component.
+type NamedSchema struct {
+	Name   string
+	Schema *Schema
+}
+
+// Schemas is a JSON object of schemas that keeps the order of its keys, so
+// generated structs list their fields in the order of the document.
+type Schemas []NamedSchema
+
+// UnmarshalJSON decodes a JSON object of schemas in key order.
+func (s *Schemas) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("schemas must be a JSON object")
+	}
+	*s = nil
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var schema Schema
+		if err := dec.Decode(&schema); err != nil {
+			return fmt.Errorf("schema %v: %w", tok, err)
+		}
+		*s = append(*s, NamedSchema{Name: tok.(string), Schema: &schema})
+	}
+	return nil
+}
+
+// Get returns the schema named name, or nil.
+func (s Schemas) Get(name string) *Schema {
+	for _, ns := range s {
+		if ns.Name == name {
+			return ns.Schema
+		}
+	}
+	return nil
+}
+
+// Load parses an OpenAPI document and checks that its references resolve.
+func Load(b []byte) (*Document, error) {
+	var d Document
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(d.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", d.OpenAPI)
+	}
+	for template, item := range d.Paths {
+		for method, op := range item.Operations() {
+			if err := d.checkOperation(op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, template, err)
+			}
+		}
+	}
+	for _, ns := range d.Components.Schemas {
+		if err := d.checkSchema(ns.Schema); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", ns.Name, err)
+		}
+	}
+	return &d, nil
+}
+
+func (d *Document) checkOperation(op *Operation) error {
+	for _, p := range op.Parameters {
+		if _, err := d.parameter(p); err != nil {
+			return err
+		}
+	}
+	if op.RequestBody != nil {
+		if err := d.checkSchema(op.RequestBody.Content["application/json"].schema()); err != nil {
+			return err
+		}
+	}
+	for status, r := range op.Responses {
+		r, err := d.response(r)
+		if err != nil {
+			return fmt.Errorf("response %s: %w", status, err)
+		}
+		if err := d.checkSchema(r.Content["application/json"].schema()); err != nil {
+			return fmt.Errorf("response %s: %w", status, err)
+		}
+	}
+	return nil
+}
+
+func (d *Document) checkSchema(s *Schema) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		_, err := d.Resolve(s)
+		return err
+	}
+	for _, p := range s.Properties {
+		if err := d.checkSchema(p.Schema); err != nil {
+			return fmt.Errorf("property %s: %w", p.Name, err)
+		}
+	}
+	for _, alt := range s.OneOf {
+		if err := d.checkSchema(alt); err != nil {
+			return err
+		}
+	}
+	return d.checkSchema(s.Items)
+}
+
+func (m *MediaType) schema() *Schema {
+	if m == nil {
+		return nil
+	}
+	return m.Schema
+}
+
+// Resolve follows s's reference to a component schema, if it has one.
+func (d *Document) Resolve(s *Schema) (*Schema, error) {
+	if s.Ref == "" {
+		return s, nil
+	}
+	name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported schema reference %q", s.Ref)
+	}
+	target := d.Components.Schemas.Get(name)
+	if target == nil {
+		return nil, fmt.Errorf("unknown schema %q", name)
+	}
+	return target, nil
+}
+
+func (d *Document) parameter(p *Parameter) (*Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	if target := d.Components.Parameters[name]; ok && target != nil {
+		return target, nil
+	}
+	return nil, fmt.Errorf("unknown parameter reference %q", p.Ref)
+}
+
+func (d *Document) response(r *Response) (*Response, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+	name, ok := strings.CutPrefix(r.Ref, "#/components/responses/")
+	if target := d.Components.Responses[name]; ok && target != nil {
+		return target, nil
+	}
+	return nil, fmt.Errorf("unknown response reference %q", r.Ref)
+}
+
+// Match returns the path template and operation that serve method and
+// path, preferring templates with more literal segments.
+func (d *Document) Match(method, path string) (string, *Operation, bool) {
+	var (
+		best     string
+		bestOp   *Operation
+		literals = -1
+	)
+	for template, item := range d.Paths {
+		op := item.Operations()[method]
+		if op == nil {
+			continue
+		}
+		if n, ok := matchTemplate(template, path); ok && n > literals {
+			best, bestOp, literals = template, op, n
+		}
+	}
+	return best, bestOp, bestOp != nil
+}
+
+// matchTemplate reports whether path matches template and how many of the
+// template's segments are literal.
+func matchTemplate(template, path string) (int, bool) {
+	ts, ps := strings.Split(template, "/"), strings.Split(path, "/")
+	if len(ts) != len(ps) {
+		return 0, false
+	}
+	literals := 0
+	for i, t := range ts {
+		switch {
+		case strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}"):
+			if ps[i] == "" {
+				return 0, false
+			}
+		case t == ps[i]:
+			literals++
+		default:
+			return 0, false
+		}
+	}
+	return literals, true
+}
+
+// Parameters returns the parameters of op on the path template, resolved,
+// including those declared on the path item.
+func (d *Document) Parameters(template string, op *Operation) ([]*Parameter, error) {
+	var params []*Parameter
+	for _, p := range append(append([]*Parameter{}, d.Paths[template].Parameters...), op.Parameters...) {
+		p, err := d.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+// RequestSchema returns the JSON schema of op's request body, or nil if op
+// takes no body.
+func (d *Document) RequestSchema(op *Operation) *Schema {
+	if op.RequestBody == nil {
+		return nil
+	}
+	return op.RequestBody.Content["application/json"].schema()
+}
+
+// ResponseSchema returns the JSON schema of op's response with the given
+// status, whether the status is specified at all, and nil for responses
+// without a JSON body.
+func (d *Document) ResponseSchema(op *Operation, status int) (*Schema, bool) {
+	r, ok := op.Responses[fmt.Sprint(status)]
+	if !ok {
+		return nil, false
+	}
+	r, err := d.response(r)
+	if err != nil {
+		return nil, false
+	}
+	return r.Content["application/json"].schema(), true
+}
@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/things/{id}": {"get": {"operationId": "getThing", "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Thing"}}}}}}},
+    "/things/id/{id}": {"get": {"operationId": "getThingByID", "responses": {}}}
+  },
+  "components": {
+    "schemas": {
+      "Thing": {
+        "description": "A thing.",
+        "type": "object",
+        "required": ["id"],
+        "properties": {
+          "id": {"type": "string"},
+          "tags": {"type": "array", "nullable": true, "items": {"type": "string"}},
+          "owner_url": {"type": "string"}
+        }
+      }
+    }
+  }
+}`
+
+func TestMatchPrefersLiteralSegments(t *testing.T) {
+	doc, err := Load([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"/things/A":    "getThing",
+		"/things/id/A": "getThingByID",
+		"/things/id":   "getThing",
+	} {
+		_, op, ok := doc.Match("GET", path)
+		if !ok || op.OperationID != want {
+			t.Errorf("Match(%s) = %v, want %s", path, op, want)
+		}
+	}
+	if _, _, ok := doc.Match("DELETE", "/things/A"); ok {
+		t.Error("matched unspecified method")
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	doc, err := Load([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := doc.Components.Schemas.Get("Thing")
+	for body, want := range map[string]string{
+		`{"id":"A"}`:                 "",
+		`{"id":"A","tags":null}`:     "",
+		`{"id":"A","tags":["x"]}`:    "",
+		`{"tags":[]}`:                `missing required property "id"`,
+		`{"id":1}`:                   "$.id: want string",
+		`{"id":"A","tags":[1]}`:      "$.tags[0]: want string",
+		`{"id":"A","colour":"blue"}`: `unknown property "colour"`,
+		`null`:                       "must not be null",
+	} {
+		err := doc.ValidateJSON(schema, []byte(body))
+		switch {
+		case want == "" && err != nil:
+			t.Errorf("%s: %v", body, err)
+		case want != "" && (err == nil || !strings.Contains(err.Error(), want)):
+			t.Errorf("%s: err = %v, want %q", body, err, want)
+		}
+	}
+}
+
+func TestGenerateModels(t *testing.T) {
+	doc, err := Load([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := doc.GenerateModels("things", "test.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// Code generated by dob-modelgen from test.json; DO NOT EDIT.",
+		"// Thing is a thing.",
+		"ID       string   `json:\"id\"`",
+		"Tags     []string `json:\"tags,omitempty\"`",
+		"OwnerURL string   `json:\"owner_url,omitempty\"`",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+}
+
+func TestLoadRejectsDanglingReferences(t *testing.T) {
+	_, err := Load([]byte(strings.ReplaceAll(testSpec, "#/components/schemas/Thing", "#/components/schemas/Nope")))
+	if err == nil {
+		t.Fatal("want error for unknown schema")
+	}
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidateJSON checks that the JSON document b conforms to s.
+func (d *Document) ValidateJSON(s *Schema, b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return d.Validate(s, v)
+}
+
+// Validate checks that v, as decoded by encoding/json, conforms to s.
+// Objects must not have properties s does not declare.
+func (d *Document) Validate(s *Schema, v any) error {
+	return d.validate(s, v, "$")
+}
+
+func (d *Document) validate(s *Schema, v any, at string) error {
+	s, err := d.Resolve(s)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		if s.Nullable {
+			return nil
+		}
+		return fmt.Errorf("%s: must not be null", at)
+	}
+
+	if len(s.OneOf) > 0 {
+		var matched int
+		var errs []error
+		for _, alt := range s.OneOf {
+			if err := d.validate(alt, v, at); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			matched++
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: must match exactly one alternative, matched %d: %w", at, matched, errors.Join(errs...))
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: want object, got %s", at, kind(v))
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", at, name)
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			prop := s.Properties.Get(name)
+			if prop == nil {
+				return fmt.Errorf("%s: unknown property %q", at, name)
+			}
+			if err := d.validate(prop, obj[name], at+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: want array, got %s", at, kind(v))
+		}
+		for i, item := range items {
+			if err := d.validate(s.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: want string, got %s", at, kind(v))
+		}
+	case "integer":
+		n, ok := v.(json.Number)
+		if _, err := n.Int64(); !ok || err != nil {
+			return fmt.Errorf("%s: want integer, got %s", at, kind(v))
+		}
+	case "number":
+		if _, ok := v.(json.Number); !ok {
+			return fmt.Errorf("%s: want number, got %s", at, kind(v))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: want boolean, got %s", at, kind(v))
+		}
+	case "":
+	default:
+		return fmt.Errorf("%s: unsupported schema type %q", at, s.Type)
+	}
+	return nil
+}
+
+func kind(v any) string {
+	switch v := v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case json.Number, float64:
+		return "number"
+	case nil:
+		return "null"
+	default:
+		return strings.TrimPrefix(fmt.Sprintf("%T", v), "*")
+	}
+}
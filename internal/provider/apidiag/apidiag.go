@@ -0,0 +1,79 @@
+// Package apidiag turns DOB API client errors into Terraform diagnostics.
+package apidiag
+
+import (
+	"context"
+	"errors"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Fields maps field names used in DOB API validation errors to the
+// attribute paths of a resource schema.
+type Fields map[string]path.Path
+
+// AddError appends a diagnostic for err to diags. Validation errors (422)
+// that name a field present in fields are reported against that attribute;
+// 401 and 403 responses are reported as authentication failures; timeouts
+// name the setting that bounds them; anything else becomes a single error
+// whose detail is detail followed by the error text.
+func AddError(diags *diag.Diagnostics, summary, detail string, err error, fields Fields) {
+	addError(diags, summary, detail, err, fields,
+		"The operation did not finish within its timeout, including retries. "+
+			"Raise it in the timeouts block of the resource if the API needs longer.")
+}
+
+// AddDataSourceError is AddError for data sources. They have no timeouts
+// block, so a read that runs out of time is pointed at request_timeout of
+// the provider instead.
+func AddDataSourceError(diags *diag.Diagnostics, summary, detail string, err error) {
+	addError(diags, summary, detail, err, nil,
+		"The read did not finish in time, including retries. "+
+			"Raise request_timeout of the dob provider if the API is slow to answer under load.")
+}
+
+// addError is AddError with the advice given when err is a deadline.
+func addError(diags *diag.Diagnostics, summary, detail string, err error, fields Fields, deadlineHint string) {
+	switch {
+	case client.IsUnauthorized(err):
+		diags.AddError(
+			"Authentication Failed",
+			detail+err.Error()+"\n\nThe DOB API did not accept the provider's credentials. "+
+				"Check the token, username/password, credential_process or oauth2 settings of the dob provider.",
+		)
+		return
+	case client.IsForbidden(err):
+		diags.AddError(
+			"Authentication Failed",
+			detail+err.Error()+"\n\nThe provider's credentials were accepted but are not allowed to perform this operation.",
+		)
+		return
+	case client.IsRequestTimeout(err):
+		diags.AddError(summary, detail+err.Error()+"\n\nA request to the DOB API did not finish within request_timeout of the dob provider. "+
+			"Raise it if the API is slow to answer under load.")
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		diags.AddError(summary, detail+err.Error()+"\n\n"+deadlineHint)
+		return
+	}
+
+	if apiErr, ok := client.AsAPIError(err); ok && client.IsValidation(err) {
+		var unmapped []client.FieldError
+		for _, fe := range apiErr.FieldErrors() {
+			p, ok := fields[fe.Field]
+			if !ok {
+				unmapped = append(unmapped, fe)
+				continue
+			}
+			diags.AddAttributeError(p, summary, fe.Message)
+		}
+		if len(unmapped) == 0 && len(apiErr.FieldErrors()) > 0 {
+			return
+		}
+	}
+
+	diags.AddError(summary, detail+err.Error())
+}
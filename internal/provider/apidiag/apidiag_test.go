@@ -0,0 +1,121 @@
+package apidiag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAddErrorMapsValidationFields(t *testing.T) {
+	err := &client.APIError{
+		StatusCode: 422,
+		Payload: &client.ErrorPayload{
+			Message: "validation failed",
+			Errors:  []client.FieldError{{Field: "email", Message: "must be a valid email address"}},
+		},
+	}
+
+	var diags diag.Diagnostics
+	AddError(&diags, "Error creating Engineer", "Could not create Engineer: ", err, Fields{"email": path.Root("email")})
+
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %d: %v", len(diags), diags)
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("email")) {
+		t.Fatalf("expected an attribute diagnostic on email, got %#v", diags[0])
+	}
+	if diags[0].Detail() != "must be a valid email address" {
+		t.Fatalf("unexpected detail %q", diags[0].Detail())
+	}
+}
+
+func TestAddErrorFallsBackToGenericError(t *testing.T) {
+	cases := map[string]error{
+		"unmapped field": &client.APIError{
+			StatusCode: 422,
+			Payload:    &client.ErrorPayload{Errors: []client.FieldError{{Field: "team", Message: "unknown"}}},
+		},
+		"server error": &client.APIError{StatusCode: 500},
+		"transport":    errors.New("connection refused"),
+	}
+	for name, err := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			AddError(&diags, "Error creating Engineer", "Could not create Engineer: ", err, Fields{"email": path.Root("email")})
+
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %d", len(diags))
+			}
+			if _, ok := diags[0].(diag.DiagnosticWithPath); ok {
+				t.Fatalf("expected a diagnostic without attribute path, got %#v", diags[0])
+			}
+			if want := "Could not create Engineer: " + err.Error(); diags[0].Detail() != want {
+				t.Fatalf("detail = %q, want %q", diags[0].Detail(), want)
+			}
+		})
+	}
+}
+
+func TestAddErrorReportsAuthenticationFailures(t *testing.T) {
+	for _, status := range []int{401, 403} {
+		var diags diag.Diagnostics
+		AddError(&diags, "Error Reading Dev", "Could not read Dev: ", &client.APIError{StatusCode: status}, nil)
+
+		if len(diags) != 1 || diags[0].Summary() != "Authentication Failed" {
+			t.Fatalf("status %d: expected an authentication failure, got %v", status, diags)
+		}
+	}
+}
+
+func TestAddErrorNamesTimeoutSetting(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"request": {
+			&url.Error{Op: "Get", URL: "http://dob/engineers", Err: &timeoutError{}},
+			"request_timeout",
+		},
+		"operation": {
+			fmt.Errorf("POST http://dob/devops: %w", context.DeadlineExceeded),
+			"timeouts block",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			AddError(&diags, "Error creating DevOps", "Could not create DevOps: ", tc.err, nil)
+
+			if len(diags) != 1 || !strings.Contains(diags[0].Detail(), tc.want) {
+				t.Fatalf("diagnostics = %v, want one naming %s", diags, tc.want)
+			}
+		})
+	}
+}
+
+func TestAddDataSourceErrorNamesRequestTimeout(t *testing.T) {
+	// Data sources have no timeouts block to point at.
+	var diags diag.Diagnostics
+	AddDataSourceError(&diags, "Unable to read Dev groups", "", fmt.Errorf("GET http://dob/dev: %w", context.DeadlineExceeded))
+
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail(), "request_timeout") || strings.Contains(diags[0].Detail(), "timeouts block") {
+		t.Fatalf("diagnostics = %v, want one naming request_timeout", diags)
+	}
+}
+
+// timeoutError is a transport error that timed out, like the one
+// http.Client returns when its Timeout expires.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "Client.Timeout exceeded while awaiting headers" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
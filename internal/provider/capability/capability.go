@@ -0,0 +1,65 @@
+// Package capability adapts resources to the features of the DOB API
+// version negotiated when the provider is configured. Attributes backed by
+// an optional capability are sent only to servers that have it; against
+// other servers the configured value is kept in state, so it does not show
+// as drift, and plans warn that it is not applied.
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Supported reports whether c's API version has capability, negotiating
+// the version first if c deferred it. A nil client, as before the provider
+// is configured, supports nothing.
+func Supported(ctx context.Context, c *client.Client, capability string) bool {
+	return c != nil && c.EnsureNegotiated(ctx) == nil && c.Supports(capability)
+}
+
+// WarnUnsupported adds a warning to diags if the string attribute at p is
+// set in config although c's API version lacks capability. Clients that
+// have not negotiated yet are given the benefit of the doubt.
+func WarnUnsupported(ctx context.Context, c *client.Client, capability string, config tfsdk.Config, p path.Path, diags *diag.Diagnostics) {
+	if c == nil || !c.Negotiated() || c.Supports(capability) {
+		return
+	}
+	var value types.String
+	diags.Append(config.GetAttribute(ctx, p, &value)...)
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	version := c.APIVersion()
+	if version == "" {
+		version = "unversioned"
+	}
+	diags.AddAttributeWarning(p,
+		"Attribute Not Supported by the DOB API",
+		fmt.Sprintf("The DOB API (version %s) does not have the %q capability, so %s is kept in Terraform state but not sent to it. "+
+			"It takes effect once the API is upgraded.", version, capability, p))
+}
+
+// String returns remote, the value read from the API, or prior if c's API
+// version lacks capability and so never returns the attribute.
+func String(c *client.Client, capability string, remote, prior types.String) types.String {
+	if c != nil && c.Supports(capability) {
+		return remote
+	}
+	return prior
+}
+
+// OptionalString maps an API string that is omitted when empty to a
+// Terraform value, null for "".
+func OptionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
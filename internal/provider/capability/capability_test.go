@@ -0,0 +1,95 @@
+package capability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// negotiated returns a client that negotiated against a server offering
+// meta, or an unversioned API if meta is empty.
+func negotiated(t *testing.T, meta string) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if meta == "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(meta))
+	}))
+	t.Cleanup(srv.Close)
+	c, err := client.NewClient(&srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Negotiate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestWarnUnsupported(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"description": schema.StringAttribute{Optional: true},
+	}}
+	config := func(v any) tfsdk.Config {
+		return tfsdk.Config{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"description": tftypes.NewValue(tftypes.String, v),
+		})}
+	}
+	v1 := negotiated(t, `{"versions":[{"version":"v1"}]}`)
+	v2 := negotiated(t, `{"versions":[{"version":"v2","capabilities":["group_descriptions"]}]}`)
+	legacy := negotiated(t, "")
+
+	cases := map[string]struct {
+		c      *client.Client
+		config tfsdk.Config
+		warn   bool
+	}{
+		"supported":        {v2, config("Builds things"), false},
+		"unsupported":      {v1, config("Builds things"), true},
+		"unversioned":      {legacy, config("Builds things"), true},
+		"unset":            {v1, config(nil), false},
+		"unknown":          {v1, config(tftypes.UnknownValue), false},
+		"before configure": {nil, config("Builds things"), false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			WarnUnsupported(ctx, tc.c, client.CapabilityGroupDescriptions, tc.config, path.Root("description"), &diags)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tc.warn {
+				t.Errorf("warned = %v, want %v: %v", got, tc.warn, diags)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	remote, prior := types.StringValue("remote"), types.StringValue("prior")
+	v1 := negotiated(t, `{"versions":[{"version":"v1"}]}`)
+	v2 := negotiated(t, `{"versions":[{"version":"v2","capabilities":["group_descriptions"]}]}`)
+
+	if got := String(v2, client.CapabilityGroupDescriptions, remote, prior); !got.Equal(remote) {
+		t.Errorf("supported: got %s, want remote value", got)
+	}
+	if got := String(v1, client.CapabilityGroupDescriptions, remote, prior); !got.Equal(prior) {
+		t.Errorf("unsupported: got %s, want prior value", got)
+	}
+	if got := OptionalString(""); !got.IsNull() {
+		t.Errorf("OptionalString(\"\") = %s, want null", got)
+	}
+}
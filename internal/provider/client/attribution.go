@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// RequestIDHeader carries the ID of each API request. The client sets a
+	// fresh ID per request, kept across retries, and the API echoes it.
+	RequestIDHeader = "X-Request-ID"
+	// ModuleHeader carries the name of the Terraform module a request is
+	// made for, if the module declares one.
+	ModuleHeader = "X-DOB-Module"
+)
+
+// WithUserAgent sets the User-Agent header of API requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// UserAgent returns the User-Agent of the provider at providerVersion run
+// by Terraform at terraformVersion, which may be unknown.
+func UserAgent(providerVersion, terraformVersion string) string {
+	ua := "terraform-provider-dob/" + providerVersion
+	if terraformVersion != "" {
+		ua = fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s", terraformVersion, ua)
+	}
+	return ua
+}
+
+type moduleKey struct{}
+
+// ContextWithModule returns ctx with requests made with it attributed to
+// the Terraform module named module.
+func ContextWithModule(ctx context.Context, module string) context.Context {
+	if module == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, moduleKey{}, module)
+}
+
+// attribute sets the headers that identify the origin of req.
+func (c *Client) attribute(req *http.Request) {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if module, ok := req.Context().Value(moduleKey{}).(string); ok {
+		req.Header.Set(ModuleHeader, module)
+	}
+	if req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, newRequestID())
+	}
+}
+
+func newRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// requestError is a failure to get any response to a request. It carries
+// the request's ID so the failure can be matched with the API's logs.
+type requestError struct {
+	requestID string
+	err       error
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("%v (request ID %s)", e.err, e.requestID)
+}
+
+func (e *requestError) Unwrap() error { return e.err }
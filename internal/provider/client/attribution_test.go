@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	if got, want := UserAgent("1.2.3", "1.9.0"), "Terraform/1.9.0 (+https://www.terraform.io) terraform-provider-dob/1.2.3"; got != want {
+		t.Errorf("UserAgent = %q, want %q", got, want)
+	}
+	if got, want := UserAgent("dev", ""), "terraform-provider-dob/dev"; got != want {
+		t.Errorf("UserAgent without Terraform version = %q, want %q", got, want)
+	}
+}
+
+func TestRequestsAreAttributed(t *testing.T) {
+	var mu sync.Mutex
+	var headers []http.Header
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		n := len(headers)
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"A"}`))
+	}), WithUserAgent("test-agent"), WithRetry(2, 0))
+
+	ctx := ContextWithModule(context.Background(), "onboarding")
+	if _, err := c.GetEngineer(ctx, "A"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetEngineer(context.Background(), "A"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(headers) != 3 {
+		t.Fatalf("requests = %d, want 3", len(headers))
+	}
+	for i, h := range headers {
+		if ua := h.Get("User-Agent"); ua != "test-agent" {
+			t.Errorf("request %d: User-Agent = %q", i, ua)
+		}
+		if id := h.Get(RequestIDHeader); len(id) != 16 {
+			t.Errorf("request %d: request ID = %q, want 16 hex digits", i, id)
+		}
+	}
+	if headers[0].Get(RequestIDHeader) != headers[1].Get(RequestIDHeader) {
+		t.Error("retry was sent with a new request ID")
+	}
+	if headers[1].Get(RequestIDHeader) == headers[2].Get(RequestIDHeader) {
+		t.Error("separate requests share a request ID")
+	}
+	if m := headers[1].Get(ModuleHeader); m != "onboarding" {
+		t.Errorf("module header = %q, want onboarding", m)
+	}
+	if m := headers[2].Get(ModuleHeader); m != "" {
+		t.Errorf("module header without module = %q", m)
+	}
+}
+
+func TestErrorsCarryRequestID(t *testing.T) {
+	var sent string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The API does not echo the ID.
+		sent = r.Header.Get(RequestIDHeader)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	_, err := c.GetEngineer(context.Background(), "A")
+	if !strings.Contains(err.Error(), "(request ID "+sent+")") {
+		t.Errorf("err = %v, want request ID %s", err, sent)
+	}
+
+	endpoint := "http://127.0.0.1:1"
+	c, err = NewClient(&endpoint, WithRetry(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.GetEngineer(context.Background(), "A")
+	if err == nil || !strings.Contains(err.Error(), "(request ID ") {
+		t.Errorf("transport err = %v, want request ID", err)
+	}
+}
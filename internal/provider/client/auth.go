@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its reported expiry a cached token is
+// considered stale and refreshed.
+const tokenExpiryDelta = 30 * time.Second
+
+// authenticator attaches credentials to outgoing API requests.
+type authenticator interface {
+	authenticate(ctx context.Context, req *http.Request) error
+	// invalidate discards cached credentials after the API rejected them
+	// and reports whether fresh ones can be obtained for a retry.
+	invalidate() bool
+}
+
+// WithBearerToken authenticates every request with a static bearer token.
+func WithBearerToken(token string) Option {
+	return func(c *Client) error {
+		if token == "" {
+			return errors.New("bearer token must not be empty")
+		}
+		c.auth = staticAuth{header: "Bearer " + token}
+		return nil
+	}
+}
+
+// WithBasicAuth authenticates every request with HTTP basic auth.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) error {
+		if username == "" {
+			return errors.New("basic auth username must not be empty")
+		}
+		req := http.Request{Header: http.Header{}}
+		req.SetBasicAuth(username, password)
+		c.auth = staticAuth{header: req.Header.Get("Authorization")}
+		return nil
+	}
+}
+
+// OAuth2Config describes an OAuth2 client credentials grant.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// WithOAuth2ClientCredentials authenticates requests with access tokens
+// obtained from an OAuth2 token endpoint. Tokens are cached and refreshed
+// shortly before they expire, or when the API rejects them.
+func WithOAuth2ClientCredentials(cfg OAuth2Config) Option {
+	return func(c *Client) error {
+		if cfg.TokenURL == "" || cfg.ClientID == "" || cfg.ClientSecret == "" {
+			return errors.New("oauth2 token URL, client ID and client secret must all be set")
+		}
+		if _, err := url.ParseRequestURI(cfg.TokenURL); err != nil {
+			return fmt.Errorf("invalid oauth2 token URL: %w", err)
+		}
+		c.auth = &oauth2Auth{cfg: cfg, client: c}
+		return nil
+	}
+}
+
+// staticAuth sends a fixed Authorization header.
+type staticAuth struct {
+	header string
+}
+
+func (a staticAuth) authenticate(_ context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", a.header)
+	return nil
+}
+
+func (staticAuth) invalidate() bool { return false }
+
+// oauth2Auth implements the OAuth2 client credentials grant.
+type oauth2Auth struct {
+	cfg    OAuth2Config
+	client *Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (a *oauth2Auth) authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *oauth2Auth) invalidate() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+	return true
+}
+
+// accessToken returns the cached token, fetching a new one if it is
+// missing or about to expire.
+func (a *oauth2Auth) accessToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && (a.expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(a.expiry)) {
+		return a.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(a.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.cfg.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(a.cfg.ClientID), url.QueryEscape(a.cfg.ClientSecret))
+
+	res, err := a.client.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting oauth2 token: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading oauth2 token response: %w", err)
+	}
+	if res.StatusCode/100 != 2 {
+		return "", newAPIError(req, res, body)
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return "", fmt.Errorf("decoding oauth2 token response: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return "", errors.New("oauth2 token response did not contain an access_token")
+	}
+	if tok.TokenType != "" && !strings.EqualFold(tok.TokenType, "bearer") {
+		return "", fmt.Errorf("unsupported oauth2 token type %q", tok.TokenType)
+	}
+
+	a.token = tok.AccessToken
+	a.expiry = time.Time{}
+	if tok.ExpiresIn > 0 {
+		a.expiry = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	}
+	return a.token, nil
+}
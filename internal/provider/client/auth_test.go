@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaticAuth(t *testing.T) {
+	cases := map[string]struct {
+		opt  Option
+		want string
+	}{
+		"bearer": {WithBearerToken("s3cret"), "Bearer s3cret"},
+		"basic":  {WithBasicAuth("ann", "pw"), "Basic YW5uOnB3"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte(`[]`))
+			}), tc.opt)
+
+			if _, err := c.GetEngineers(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("Authorization = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var issued atomic.Int32
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if err := r.ParseForm(); err != nil || id != "cid" || secret != "csecret" ||
+			r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "read write" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		n := issued.Add(1)
+		fmt.Fprintf(w, `{"access_token":"tok-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	defer tokenSrv.Close()
+
+	// The API rejects the first token as if it had been revoked, forcing
+	// one refresh.
+	var seen []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if auth != "Bearer tok-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}), WithOAuth2ClientCredentials(OAuth2Config{
+		TokenURL:     tokenSrv.URL,
+		ClientID:     "cid",
+		ClientSecret: "csecret",
+		Scopes:       []string{"read", "write"},
+	}))
+
+	for range 2 {
+		if _, err := c.GetDev(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if issued.Load() != 2 {
+		t.Fatalf("expected exactly one refresh, %d tokens issued", issued.Load())
+	}
+	want := []string{"Bearer tok-1", "Bearer tok-2", "Bearer tok-2"}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Fatalf("API saw %v, want %v", seen, want)
+	}
+}
+
+func TestOAuth2TokenEndpointRejection(t *testing.T) {
+	var calls atomic.Int32
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+	}))
+	defer tokenSrv.Close()
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called without a token")
+	}), WithRetry(3, time.Millisecond), WithOAuth2ClientCredentials(OAuth2Config{
+		TokenURL:     tokenSrv.URL,
+		ClientID:     "cid",
+		ClientSecret: "wrong",
+	}))
+
+	_, err := c.GetEngineers(context.Background())
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("rejected credentials should not be retried, got %d token requests", got)
+	}
+}
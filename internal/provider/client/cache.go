@@ -0,0 +1,206 @@
+package client
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// WithCacheDir persists cached GET responses in dir, so their validators
+// survive across provider runs. The directory is created if needed. Bodies
+// are stored as the API sent them, without the masking applied to logs, so
+// they contain personal data such as email addresses; they are written
+// readable by the owner only. Files in dir are never removed.
+func WithCacheDir(dir string) Option {
+	return func(c *Client) error {
+		if dir == "" {
+			return errors.New("cache directory must not be empty")
+		}
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("creating cache directory: %w", err)
+		}
+		c.cache.dir = dir
+		return nil
+	}
+}
+
+// maxCacheEntries bounds the number of responses a responseCache keeps in
+// memory. The least recently used ones are dropped first.
+const maxCacheEntries = 1024
+
+// responseCache keeps the last successful GET response per URL together
+// with its ETag and Last-Modified validators. Cached responses are never
+// served without asking the API: requests carry If-None-Match and
+// If-Modified-Since, and a 304 answer reuses the cached body.
+type responseCache struct {
+	// dir, if set, is where entries are persisted.
+	dir string
+
+	mu sync.Mutex
+	// entries holds the list elements of recent, holding *cacheEntry, by
+	// URL; recent is ordered from the most recently used.
+	entries map[string]*list.Element
+	recent  *list.List
+}
+
+type cacheEntry struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         []byte      `json:"body"`
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: map[string]*list.Element{}, recent: list.New()}
+}
+
+// prepare adds conditional headers for a cached response to req and
+// returns the entry, or nil if req cannot be answered from the cache.
+func (rc *responseCache) prepare(req *http.Request) *cacheEntry {
+	if req.Method != http.MethodGet {
+		return nil
+	}
+	e := rc.get(req.URL.String())
+	if e == nil {
+		return nil
+	}
+	// Leave conditional requests of callers alone. Headers matching the
+	// entry were set by an earlier attempt of the same request.
+	if v := req.Header.Get("If-None-Match"); v != "" && v != e.ETag {
+		return nil
+	}
+	if v := req.Header.Get("If-Modified-Since"); v != "" && v != e.LastModified {
+		return nil
+	}
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+	return e
+}
+
+// store caches a successful GET response that carries a validator.
+func (rc *responseCache) store(req *http.Request, header http.Header, body []byte) {
+	if req.Method != http.MethodGet {
+		return
+	}
+	etag, lastModified := header.Get("ETag"), header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return
+	}
+	h := header.Clone()
+	h.Del("Set-Cookie")
+	rc.put(&cacheEntry{
+		URL:          req.URL.String(),
+		ETag:         etag,
+		LastModified: lastModified,
+		Header:       h,
+		Body:         body,
+	})
+}
+
+// refresh updates e with the headers of a 304 answering a request for it,
+// as RFC 9111 section 4.3.4 requires, and returns the stored result.
+func (rc *responseCache) refresh(e *cacheEntry, header http.Header) *cacheEntry {
+	h := e.Header.Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	for k, v := range header {
+		switch k {
+		case "Content-Length", "Content-Type", "Set-Cookie":
+			// These describe the 304 itself, not the stored body.
+		default:
+			h[k] = v
+		}
+	}
+	updated := &cacheEntry{
+		URL:          e.URL,
+		ETag:         h.Get("ETag"),
+		LastModified: h.Get("Last-Modified"),
+		Header:       h,
+		Body:         e.Body,
+	}
+	rc.put(updated)
+	return updated
+}
+
+func (rc *responseCache) get(url string) *cacheEntry {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if el, ok := rc.entries[url]; ok {
+		rc.recent.MoveToFront(el)
+		return el.Value.(*cacheEntry)
+	}
+	if rc.dir == "" {
+		return nil
+	}
+
+	b, err := os.ReadFile(rc.path(url))
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	// A corrupt or colliding file is treated as a miss and overwritten on
+	// the next store.
+	if json.Unmarshal(b, &e) != nil || e.URL != url {
+		return nil
+	}
+	rc.remember(&e)
+	return &e
+}
+
+func (rc *responseCache) put(e *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.remember(e)
+	if rc.dir == "" {
+		return
+	}
+
+	// The cache is an optimisation; failing to persist it is not an error.
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(rc.dir, "entry-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err != nil || cerr != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), rc.path(e.URL))
+}
+
+// remember keeps e in memory as the most recently used entry, dropping the
+// least recently used one beyond maxCacheEntries. rc.mu must be held.
+func (rc *responseCache) remember(e *cacheEntry) {
+	if el, ok := rc.entries[e.URL]; ok {
+		el.Value = e
+		rc.recent.MoveToFront(el)
+		return
+	}
+	rc.entries[e.URL] = rc.recent.PushFront(e)
+	if rc.recent.Len() > maxCacheEntries {
+		oldest := rc.recent.Remove(rc.recent.Back()).(*cacheEntry)
+		delete(rc.entries, oldest.URL)
+	}
+}
+
+func (rc *responseCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:])+".json")
+}
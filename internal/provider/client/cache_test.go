@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// etagHandler serves an engineer with an ETag, answering 304 when the
+// request's If-None-Match matches, and counts full responses.
+func etagHandler(full *atomic.Int32, etag *atomic.Value) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := etag.Load().(string)
+		w.Header().Set("ETag", current)
+		if r.Header.Get("If-None-Match") == current {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		_, _ = w.Write([]byte(`{"id":"A","name":"` + strings.Trim(current, `"`) + `"}`))
+	})
+}
+
+func TestConditionalGetReusesCachedBody(t *testing.T) {
+	var full atomic.Int32
+	var etag atomic.Value
+	etag.Store(`"v1"`)
+	c := newTestClient(t, etagHandler(&full, &etag))
+	ctx := context.Background()
+
+	for range 3 {
+		e, err := c.GetEngineer(ctx, "A")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Name != "v1" {
+			t.Errorf("name = %q, want cached v1", e.Name)
+		}
+	}
+	if n := full.Load(); n != 1 {
+		t.Errorf("full responses = %d, want 1", n)
+	}
+
+	// A changed object is fetched again.
+	etag.Store(`"v2"`)
+	e, err := c.GetEngineer(ctx, "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "v2" {
+		t.Errorf("name = %q, want v2", e.Name)
+	}
+}
+
+func TestConditionalGetLastModified(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2026 07:28:00 GMT"
+	var full atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(`[{"id":"A"}]`))
+	}))
+
+	for range 2 {
+		items, err := c.GetEngineers(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 {
+			t.Errorf("items = %+v", items)
+		}
+	}
+	if n := full.Load(); n != 1 {
+		t.Errorf("full responses = %d, want 1", n)
+	}
+}
+
+func TestCacheDirSurvivesClients(t *testing.T) {
+	dir := t.TempDir()
+	var full atomic.Int32
+	var etag atomic.Value
+	etag.Store(`"v1"`)
+	srv := httptest.NewServer(etagHandler(&full, &etag))
+	defer srv.Close()
+
+	for range 2 {
+		c, err := NewClient(&srv.URL, WithCacheDir(dir))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GetEngineer(context.Background(), "A"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := full.Load(); n != 1 {
+		t.Errorf("full responses = %d, want 1 across clients", n)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("cache files = %d, want 1", len(files))
+	}
+	info, err := files[0].Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Errorf("cache file mode = %v, want owner only", perm)
+	}
+}
+
+func TestNotModifiedWithoutCacheIsError(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}), WithRetry(1, 0))
+	if _, err := c.GetEngineer(context.Background(), "A"); !HasStatus(err, http.StatusNotModified) {
+		t.Fatalf("err = %v, want 304 API error", err)
+	}
+}
+
+func TestNotModifiedUpdatesCachedHeaders(t *testing.T) {
+	var conditions []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditions = append(conditions, r.Header.Get("If-None-Match"))
+		switch r.Header.Get("If-None-Match") {
+		case "":
+			w.Header().Set("ETag", `W/"v1"`)
+			_, _ = w.Write([]byte(`{"id":"A","name":"Ann"}`))
+		default:
+			// The representation is unchanged, but the API now names it
+			// with a strong validator.
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusNotModified)
+		}
+	}))
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := c.GetEngineer(ctx, "A"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e, version, err := c.Engineers.GetVersioned(ctx, "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "Ann" || version != `"v1"` {
+		t.Errorf("got %+v with version %s, want the cached body with the new ETag", e, version)
+	}
+	if got := strings.Join(conditions, " "); got != ` W/"v1" "v1"` {
+		t.Errorf("If-None-Match sent = %q, want the ETag of the latest 304", got)
+	}
+}
+
+func TestResponseCacheIsBounded(t *testing.T) {
+	rc := newResponseCache()
+	header := http.Header{"Etag": {`"v"`}}
+	for i := range maxCacheEntries + 1 {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://dob/engineers/id/%d", i), nil)
+		rc.store(req, header, []byte(`{}`))
+		if i == 1 {
+			// Using the first entry keeps it over the second one.
+			rc.get("http://dob/engineers/id/0")
+		}
+	}
+	if n := len(rc.entries); n != maxCacheEntries {
+		t.Fatalf("entries = %d, want %d", n, maxCacheEntries)
+	}
+	if rc.get("http://dob/engineers/id/0") == nil || rc.get("http://dob/engineers/id/1") != nil {
+		t.Error("cache did not drop the least recently used entry")
+	}
+}
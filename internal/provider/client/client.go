@@ -1,50 +1,271 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type Client struct {
-	endpoint    string
-	HTTPClient *http.Client
+	// endpoint is the base URL requests are built on, and endpointName the
+	// endpoint as configured, which differ for a Unix socket endpoint.
+	endpoint     string
+	endpointName string
+	HTTPClient   *http.Client
+
+	// Typed clients for each DOB API entity type.
+	Engineers *Collection[Engineer]
+	Devs      *Collection[Dev]
+	Ops       *Collection[Ops]
+	DevOps    *Collection[DevOps]
+
+	transport *http.Transport
+	retry     retryPolicy
+	auth      authenticator
+	throttle  *throttle
+	cache     *responseCache
+	flights   flightGroup
+	userAgent string
+
+	// endpoints routes requests between the endpoint and those added by
+	// WithFailoverEndpoints.
+	endpoints endpointPool
+
+	// sockets maps the placeholder hosts of Unix socket endpoints to the
+	// paths of their sockets.
+	sockets map[string]string
+
+	// api is set by Negotiate; see also WithDeferredNegotiation.
+	api              atomic.Pointer[negotiatedAPI]
+	deferNegotiation bool
+	negotiating      sync.Mutex
+
+	// primeReads serves single-object reads from one list request per
+	// collection; see WithPrimedReads.
+	primeReads bool
+
+	// maskedFields holds the lower-cased JSON fields and headers whose
+	// values are masked in logs.
+	maskedFields map[string]bool
 }
 
+// Option configures optional Client behaviour in NewClient.
+type Option func(*Client) error
+
 // NewClient -
-func NewClient(endpoint *string) (*Client, error) {
-    c := Client{
-        HTTPClient: &http.Client{Timeout: 10 * time.Second},
-        endpoint:   "",
-    }
+func NewClient(endpoint *string, opts ...Option) (*Client, error) {
+	transport := newTransport()
+	c := Client{
+		HTTPClient:   &http.Client{Timeout: DefaultRequestTimeout, Transport: transport},
+		endpoint:     "",
+		transport:    transport,
+		maskedFields: maskSet(DefaultMaskedLogFields),
+		throttle:     newThrottle(DefaultMaxConcurrentRequests),
+		cache:        newResponseCache(),
+		retry: retryPolicy{
+			maxAttempts: DefaultMaxAttempts,
+			maxBackoff:  DefaultMaxBackoff,
+		},
+	}
+
+	if endpoint != nil {
+		c.endpointName = strings.TrimSuffix(*endpoint, "/")
+		c.endpoint = c.resolveEndpoint(*endpoint)
+	}
 
-    if endpoint != nil {
-        c.endpoint = *endpoint
-    }
+	c.Engineers = NewCollection[Engineer](&c, RESTRoutes("/engineers"))
+	c.Devs = NewCollection[Dev](&c, RESTRoutes("/dev"))
+	c.Ops = NewCollection[Ops](&c, RESTRoutes("/op"))
+	c.DevOps = NewCollection[DevOps](&c, DevOpsRoutes("/devops"))
 
-    return &c, nil
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return nil, err
+		}
+	}
+	c.dialSockets()
+
+	return &c, nil
+}
+
+// response is a successful API response.
+type response struct {
+	body   []byte
+	header http.Header
 }
 
+// doRequest sends req, retrying according to the client's retry policy, and
+// returns the body of the first successful response.
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-    // Basic request logging to aid debugging
-    fmt.Printf("[client] HTTP %s %s\n", req.Method, req.URL.String())
-    res, err := c.HTTPClient.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer res.Body.Close()
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return res.body, nil
+}
+
+// do is doRequest for callers that also need the response headers.
+// Identical concurrent GETs are coalesced into one API request.
+func (c *Client) do(req *http.Request) (*response, error) {
+	if key, ok := flightKey(req); ok {
+		return c.flights.do(req.Context(), key, func() (*response, error) {
+			return c.doWithRetry(req)
+		})
+	}
+	res, err := c.doWithRetry(req)
+	if req.Method != http.MethodGet {
+		c.flights.forget()
+	}
+	return res, err
+}
+
+// doWithRetry sends req, retrying according to the client's retry policy.
+func (c *Client) doWithRetry(req *http.Request) (*response, error) {
+	ctx := logContext(req.Context())
+	reauthenticated := false
+	c.attribute(req)
+
+	failovers := 0
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.Body != nil {
+			// The previous attempt consumed the body; rewind it.
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("%s %s: request body cannot be replayed for retry", req.Method, req.URL.String())
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		body, status, header, err := c.send(ctx, req, attempt)
+		if err == nil {
+			return &response{body: body, header: header}, nil
+		}
+
+		// A rejected token may simply have expired; fetch a fresh one and
+		// try once more before giving up.
+		if status == http.StatusUnauthorized && !reauthenticated && c.auth != nil && c.auth.invalidate() {
+			reauthenticated = true
+			continue
+		}
+
+		// A read that failed because its endpoint is unavailable is repeated
+		// on another endpoint right away, without counting as a retry.
+		if safeMethods[req.Method] && failovers < len(c.endpoints.urls)-1 && c.endpoints.canFailOver(req.URL) {
+			failovers++
+			tflog.SubsystemWarn(ctx, LogSubsystem, "Failing over DOB API request", map[string]interface{}{
+				"http_method": req.Method,
+				"http_url":    req.URL.String(),
+				"http_status": status,
+				"error":       err.Error(),
+			})
+			continue
+		}
+
+		retry := attempt-failovers < c.retry.maxAttempts && c.retry.shouldRetry(ctx, req.Method, status, err)
+		wait := c.retry.backoff(attempt, header)
+		if retry && outlastsDeadline(req.Context(), wait) {
+			// Report why the last attempt failed rather than waiting for
+			// the operation to time out.
+			tflog.SubsystemWarn(ctx, LogSubsystem, "Not retrying DOB API request past its deadline", map[string]interface{}{
+				"http_method": req.Method,
+				"http_url":    req.URL.String(),
+				"attempt":     attempt,
+				"wait":        wait.String(),
+			})
+			retry = false
+		}
+		if !retry {
+			if _, ok := AsAPIError(err); !ok {
+				err = &requestError{requestID: req.Header.Get(RequestIDHeader), err: err}
+			}
+			return nil, err
+		}
+
+		tflog.SubsystemWarn(ctx, LogSubsystem, "Retrying DOB API request", map[string]interface{}{
+			"http_method":  req.Method,
+			"http_url":     req.URL.String(),
+			"http_status":  status,
+			"attempt":      attempt,
+			"max_attempts": c.retry.maxAttempts,
+			"wait":         wait.String(),
+			"error":        err.Error(),
+		})
+		if err := sleep(ctx, wait); err != nil {
+			return nil, &requestError{
+				requestID: req.Header.Get(RequestIDHeader),
+				err:       fmt.Errorf("%s %s: %w", req.Method, req.URL.String(), err),
+			}
+		}
+	}
+}
+
+// send performs a single attempt of req, logging to the subsystem attached
+// to ctx. The status is 0 and the header nil when no response was received.
+func (c *Client) send(ctx context.Context, req *http.Request, attempt int) ([]byte, int, http.Header, error) {
+	endpoint := c.endpoints.route(ctx, req)
+
+	if c.auth != nil {
+		if err := c.auth.authenticate(req.Context(), req); err != nil {
+			return nil, 0, nil, err
+		}
+	}
+
+	release, err := c.throttle.acquire(req.Context())
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.String(), err)
+	}
+	defer release()
+
+	cached := c.cache.prepare(req)
+
+	c.logRequest(ctx, req, attempt)
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "DOB API request failed", map[string]interface{}{
+			"http_method": req.Method,
+			"http_url":    req.URL.String(),
+			"error":       err.Error(),
+		})
+		// Surface cancellation and deadlines directly so callers can tell an
+		// interrupted apply apart from a transport failure.
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, 0, nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.String(), ctxErr)
+		}
+		c.endpoints.report(ctx, endpoint, false)
+		return nil, 0, nil, err
+	}
+	defer res.Body.Close()
+	c.endpoints.report(ctx, endpoint, res.StatusCode < 500)
+	c.throttle.observe(res.Header, time.Now())
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, res.StatusCode, res.Header, err
+	}
+	c.logResponse(ctx, req, res, body)
+
+	if res.StatusCode == http.StatusNotModified && cached != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Reusing cached DOB API response", map[string]interface{}{
+			"http_url": req.URL.String(),
+		})
+		cached = c.cache.refresh(cached, res.Header)
+		return cached.Body, http.StatusOK, cached.Header, nil
 	}
 
-    if !(res.StatusCode/100 == 2){
-        // Log non-2xx to aid debugging
-        fmt.Printf("[client] HTTP %s %s -> %d, body: %s\n", req.Method, req.URL.String(), res.StatusCode, string(body))
-        return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
-    }
+	if !(res.StatusCode/100 == 2) {
+		return nil, res.StatusCode, res.Header, newAPIError(req, res, body)
+	}
+	c.cache.store(req, res.Header, body)
 
-    return body, err
-}
\ No newline at end of file
+	return body, res.StatusCode, res.Header, nil
+}
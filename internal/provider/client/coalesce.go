@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// flightGroup coalesces identical GET requests: while one is in flight,
+// further requests for the same URL wait for it and share its response
+// instead of going to the API themselves.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done chan struct{}
+	res  *response
+	err  error
+}
+
+// flightKey returns the key req is coalesced under, or false if it must be
+// sent on its own. Only plain GETs are coalesced; conditional requests of
+// callers expect an answer to their own preconditions. Requests made for
+// different modules are kept apart so each is attributed correctly.
+func flightKey(req *http.Request) (string, bool) {
+	if req.Method != http.MethodGet || req.Body != nil {
+		return "", false
+	}
+	for _, h := range []string{"If-Match", "If-None-Match", "If-Modified-Since", "Range"} {
+		if req.Header.Get(h) != "" {
+			return "", false
+		}
+	}
+	key := req.URL.String()
+	if module, ok := req.Context().Value(moduleKey{}).(string); ok {
+		key += " " + module
+	}
+	return key, true
+}
+
+// do calls fn for key unless a call for key is already in flight, in which
+// case it waits for that call and returns its result. The response is
+// shared between callers and must not be modified.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*response, error)) (*response, error) {
+	for {
+		g.mu.Lock()
+		if g.flights == nil {
+			g.flights = map[string]*flight{}
+		}
+		f, inFlight := g.flights[key]
+		if !inFlight {
+			f = &flight{done: make(chan struct{})}
+			g.flights[key] = f
+		}
+		g.mu.Unlock()
+
+		if !inFlight {
+			f.res, f.err = fn()
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+			return f.res, f.err
+		}
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// The call we joined was cancelled by its own caller; make our own
+		// unless we were cancelled too.
+		if isContextError(f.err) && ctx.Err() == nil {
+			continue
+		}
+		return f.res, f.err
+	}
+}
+
+// forget detaches all calls in flight, so requests made from now on are
+// sent anew. It is called after writes, whose effect calls that started
+// earlier may not reflect.
+func (g *flightGroup) forget() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	clear(g.flights)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
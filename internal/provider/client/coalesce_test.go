@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingHandler serves engineer A once release is closed, signalling
+// each request it receives on arrived.
+func blockingHandler(calls *atomic.Int32, arrived chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		arrived <- struct{}{}
+		<-release
+		_, _ = w.Write([]byte(`{"id":"A","name":"Ann"}`))
+	})
+}
+
+func TestConcurrentGetsAreCoalesced(t *testing.T) {
+	var calls atomic.Int32
+	arrived, release := make(chan struct{}, 10), make(chan struct{})
+	c := newTestClient(t, blockingHandler(&calls, arrived, release))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	get := func() {
+		defer wg.Done()
+		e, err := c.GetEngineer(ctx, "A")
+		if err != nil || e.Name != "Ann" {
+			t.Errorf("GetEngineer = %+v, %v", e, err)
+		}
+	}
+	wg.Add(1)
+	go get()
+	<-arrived
+	for range 9 {
+		wg.Add(1)
+		go get()
+	}
+	// Give the followers time to join the request in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("API requests = %d, want 1", n)
+	}
+}
+
+func TestGetAfterWriteIsNotCoalesced(t *testing.T) {
+	var calls atomic.Int32
+	arrived, release := make(chan struct{}, 10), make(chan struct{})
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			_, _ = w.Write([]byte(`{"id":"A"}`))
+			return
+		}
+		blockingHandler(&calls, arrived, release).ServeHTTP(w, r)
+	}))
+	ctx := context.Background()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = c.GetEngineer(ctx, "A")
+	}()
+	<-arrived
+
+	// The write may have changed what the GET in flight returns.
+	if _, err := c.UpdateEngineer(ctx, "A", Engineer{Name: "Bob"}); err != nil {
+		t.Fatal(err)
+	}
+	go func() { _, _ = c.GetEngineer(ctx, "A") }()
+	select {
+	case <-arrived:
+	case <-time.After(time.Second):
+		t.Fatal("GET after write joined the earlier request")
+	}
+	close(release)
+	<-done
+}
+
+func TestCancelledLeaderDoesNotFailFollowers(t *testing.T) {
+	var calls atomic.Int32
+	arrived, release := make(chan struct{}, 10), make(chan struct{})
+	c := newTestClient(t, blockingHandler(&calls, arrived, release), WithRetry(1, 0))
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := c.GetEngineer(leaderCtx, "A")
+		leaderErr <- err
+	}()
+	<-arrived
+
+	followerErr := make(chan error)
+	go func() {
+		_, err := c.GetEngineer(context.Background(), "A")
+		followerErr <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-leaderErr; !isContextError(err) {
+		t.Fatalf("leader err = %v, want cancellation", err)
+	}
+
+	// The follower sends its own request once the leader gives up.
+	<-arrived
+	close(release)
+	if err := <-followerErr; err != nil {
+		t.Fatalf("follower err = %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Routes holds the path templates of a collection's endpoints, relative to
+// the client endpoint. "{id}" is replaced by the escaped object ID.
+type Routes struct {
+	List   string
+	Get    string
+	Create string
+	Update string
+	Patch  string
+	Delete string
+}
+
+// RESTRoutes returns the DOB API's route conventions for the collection at
+// base: reads of a single object go to base/id/{id}, writes to base/{id}.
+func RESTRoutes(base string) Routes {
+	return Routes{
+		List:   base,
+		Get:    base + "/id/{id}",
+		Create: base,
+		Update: base + "/{id}",
+		Patch:  base + "/{id}",
+		Delete: base + "/{id}",
+	}
+}
+
+// DevOpsRoutes returns the routes of the DevOps collection at base, which
+// unlike the other collections serves reads of a single object at
+// base/{id}.
+func DevOpsRoutes(base string) Routes {
+	routes := RESTRoutes(base)
+	routes.Get = base + "/{id}"
+	return routes
+}
+
+// Collection is a typed client for one DOB API entity type.
+type Collection[T any] struct {
+	client *Client
+	routes Routes
+	prime  primeCache
+}
+
+// NewCollection returns a Collection of T served by c at routes.
+func NewCollection[T any](c *Client, routes Routes) *Collection[T] {
+	return &Collection[T]{client: c, routes: routes}
+}
+
+// List iterates over all objects, fetching further pages as needed.
+func (col *Collection[T]) List(ctx context.Context) iter.Seq2[T, error] {
+	return listAll[T](ctx, col.client, col.routes.List)
+}
+
+// Get returns the object with the given ID.
+func (col *Collection[T]) Get(ctx context.Context, id string) (*T, error) {
+	obj, _, err := col.GetVersioned(ctx, id)
+	return obj, err
+}
+
+// GetVersioned returns the object with the given ID and its version, the
+// ETag the API sent with it. The version is empty if the API sent none, or
+// if the object was served from a primed list (see WithPrimedReads).
+func (col *Collection[T]) GetVersioned(ctx context.Context, id string) (*T, string, error) {
+	if col.client.primeReads {
+		if raw, ok := col.prime.lookup(ctx, col.client, col.routes.List, id); ok {
+			var obj T
+			if err := json.Unmarshal(raw, &obj); err == nil {
+				return &obj, "", nil
+			}
+		}
+	}
+	return col.send(ctx, http.MethodGet, col.path(col.routes.Get, id), nil, "")
+}
+
+// Create creates obj and returns the object as stored by the API.
+func (col *Collection[T]) Create(ctx context.Context, obj T) (*T, error) {
+	created, _, err := col.CreateVersioned(ctx, obj)
+	return created, err
+}
+
+// CreateVersioned is Create that also returns the version of the created
+// object.
+func (col *Collection[T]) CreateVersioned(ctx context.Context, obj T) (*T, string, error) {
+	return col.send(ctx, http.MethodPost, col.routes.Create, obj, "")
+}
+
+// Update replaces the object with the given ID by obj. The result is nil if
+// the API does not echo the updated object.
+func (col *Collection[T]) Update(ctx context.Context, id string, obj T) (*T, error) {
+	return col.UpdateIfMatch(ctx, id, obj, "")
+}
+
+// UpdateIfMatch is Update that only applies if the object is still at
+// version, failing with 412 Precondition Failed otherwise. An empty
+// version updates unconditionally.
+func (col *Collection[T]) UpdateIfMatch(ctx context.Context, id string, obj T, version string) (*T, error) {
+	col.prime.forget(id)
+	updated, _, err := col.send(ctx, http.MethodPut, col.path(col.routes.Update, id), obj, version)
+	return updated, err
+}
+
+// Patch applies a partial update to the object with the given ID. The
+// result is nil if the API does not echo the updated object.
+func (col *Collection[T]) Patch(ctx context.Context, id string, patch any) (*T, error) {
+	col.prime.forget(id)
+	patched, _, err := col.send(ctx, http.MethodPatch, col.path(col.routes.Patch, id), patch, "")
+	return patched, err
+}
+
+// Delete deletes the object with the given ID.
+func (col *Collection[T]) Delete(ctx context.Context, id string) error {
+	return col.DeleteIfMatch(ctx, id, "")
+}
+
+// DeleteIfMatch is Delete that only applies if the object is still at
+// version. An empty version deletes unconditionally.
+func (col *Collection[T]) DeleteIfMatch(ctx context.Context, id string, version string) error {
+	col.prime.forget(id)
+	_, _, err := col.send(ctx, http.MethodDelete, col.path(col.routes.Delete, id), nil, version)
+	return err
+}
+
+func (col *Collection[T]) path(template, id string) string {
+	return strings.ReplaceAll(template, "{id}", url.PathEscape(id))
+}
+
+// send performs a request with an optional JSON body, sent with If-Match
+// when ifMatch is set, and decodes the response into a T. Empty update
+// responses decode to nil. It also returns the response's ETag.
+func (col *Collection[T]) send(ctx context.Context, method, path string, body any, ifMatch string) (*T, string, error) {
+	req, err := col.client.newRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, "", err
+	}
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	res, err := col.client.do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	etag := res.header.Get("ETag")
+	if method == http.MethodDelete {
+		return nil, "", nil
+	}
+	if len(bytes.TrimSpace(res.body)) == 0 {
+		// Reads and creates must return the object; updates may not.
+		if method == http.MethodGet || method == http.MethodPost {
+			return nil, "", fmt.Errorf("%s %s: empty response", method, req.URL.String())
+		}
+		return nil, etag, nil
+	}
+
+	var obj T
+	if err := json.Unmarshal(res.body, &obj); err != nil {
+		return nil, "", fmt.Errorf("%s %s: decoding response: %w", method, req.URL.String(), err)
+	}
+	return &obj, etag, nil
+}
+
+// newRequest builds an API request for path, encoding body as JSON when it
+// is not nil.
+func (c *Client) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	u, err := c.url(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCollectionRoutes(t *testing.T) {
+	type call struct{ method, path, contentType, body string }
+	var calls []call
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		calls = append(calls, call{r.Method, r.URL.EscapedPath(), r.Header.Get("Content-Type"), string(b)})
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id":"a/b"}`))
+		default:
+			_, _ = w.Write(b)
+		}
+	}))
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		run  func() error
+		want []call
+	}{
+		{"engineers", func() error {
+			return exercise(ctx, c.Engineers, "a/b", Engineer{Name: "Ann"})
+		}, []call{
+			{"GET", "/engineers/id/a%2Fb", "", ""},
+			{"POST", "/engineers", "application/json", `{"id":"","name":"Ann","email":""}`},
+			{"PUT", "/engineers/a%2Fb", "application/json", `{"id":"","name":"Ann","email":""}`},
+			{"PATCH", "/engineers/a%2Fb", "application/json", `{"name":"x"}`},
+			{"DELETE", "/engineers/a%2Fb", "", ""},
+		}},
+		{"dev", func() error {
+			return exercise(ctx, c.Devs, "a/b", Dev{Name: "Team"})
+		}, []call{
+			{"GET", "/dev/id/a%2Fb", "", ""},
+			{"POST", "/dev", "application/json", `{"id":"","name":"Team","engineers":null}`},
+			{"PUT", "/dev/a%2Fb", "application/json", `{"id":"","name":"Team","engineers":null}`},
+			{"PATCH", "/dev/a%2Fb", "application/json", `{"name":"x"}`},
+			{"DELETE", "/dev/a%2Fb", "", ""},
+		}},
+		{"ops", func() error {
+			return exercise(ctx, c.Ops, "a/b", Ops{Name: "Team"})
+		}, []call{
+			{"GET", "/op/id/a%2Fb", "", ""},
+			{"POST", "/op", "application/json", `{"id":"","name":"Team","engineers":null}`},
+			{"PUT", "/op/a%2Fb", "application/json", `{"id":"","name":"Team","engineers":null}`},
+			{"PATCH", "/op/a%2Fb", "application/json", `{"name":"x"}`},
+			{"DELETE", "/op/a%2Fb", "", ""},
+		}},
+		{"devops", func() error {
+			return exercise(ctx, c.DevOps, "a/b", DevOps{})
+		}, []call{
+			{"GET", "/devops/a%2Fb", "", ""},
+			{"POST", "/devops", "application/json", `{"id":"","dev":null,"ops":null}`},
+			{"PUT", "/devops/a%2Fb", "application/json", `{"id":"","dev":null,"ops":null}`},
+			{"PATCH", "/devops/a%2Fb", "application/json", `{"name":"x"}`},
+			{"DELETE", "/devops/a%2Fb", "", ""},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+			if err := tc.run(); err != nil {
+				t.Fatal(err)
+			}
+			if len(calls) != len(tc.want) {
+				t.Fatalf("got %d calls, want %d: %+v", len(calls), len(tc.want), calls)
+			}
+			for i := range calls {
+				if calls[i] != tc.want[i] {
+					t.Errorf("call %d = %+v, want %+v", i, calls[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+// exercise runs every single-object operation of col once.
+func exercise[T any](ctx context.Context, col *Collection[T], id string, obj T) error {
+	if _, err := col.Get(ctx, id); err != nil {
+		return err
+	}
+	if _, err := col.Create(ctx, obj); err != nil {
+		return err
+	}
+	if _, err := col.Update(ctx, id, obj); err != nil {
+		return err
+	}
+	if _, err := col.Patch(ctx, id, json.RawMessage(`{"name":"x"}`)); err != nil {
+		return err
+	}
+	return col.Delete(ctx, id)
+}
+
+func TestCollectionEmptyResponses(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ctx := context.Background()
+
+	if got, err := c.Devs.Update(ctx, "d1", Dev{}); err != nil || got != nil {
+		t.Fatalf("empty update response: got %v, %v", got, err)
+	}
+	if _, err := c.Devs.Create(ctx, Dev{}); err == nil {
+		t.Fatal("expected an empty create response to be an error")
+	}
+	if _, err := c.Devs.Get(ctx, "d1"); err == nil {
+		t.Fatal("expected an empty read response to be an error")
+	}
+}
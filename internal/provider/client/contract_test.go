@@ -0,0 +1,202 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"terraform-provider-devops/api"
+	"terraform-provider-devops/internal/openapi"
+)
+
+func loadSpec(t *testing.T) *openapi.Document {
+	t.Helper()
+	doc, err := openapi.Load(api.OpenAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestModelsMatchSpec(t *testing.T) {
+	want, err := loadSpec(t).GenerateModels("client", "openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("models_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("models_gen.go is out of date with api/openapi.json; run go generate ./internal/provider/client")
+	}
+}
+
+// contractHandler answers every request with an example of the specified
+// success response after checking the request against the specification.
+// It records the operations called.
+type contractHandler struct {
+	t   *testing.T
+	doc *openapi.Document
+
+	mu     sync.Mutex
+	called map[string]bool
+}
+
+func (h *contractHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	template, op, ok := h.doc.Match(r.Method, r.URL.Path)
+	if !ok {
+		h.t.Errorf("%s %s: no such operation in the specification", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	h.mu.Lock()
+	h.called[op.OperationID] = true
+	h.mu.Unlock()
+
+	params, err := h.doc.Parameters(template, op)
+	if err != nil {
+		h.t.Error(err)
+	}
+	for _, name := range []string{"If-Match", "If-None-Match"} {
+		if r.Header.Get(name) != "" && !slices.ContainsFunc(params, func(p *openapi.Parameter) bool { return p.In == "header" && p.Name == name }) {
+			h.t.Errorf("%s: sends undeclared header %s", op.OperationID, name)
+		}
+	}
+	for q := range r.URL.Query() {
+		if !slices.ContainsFunc(params, func(p *openapi.Parameter) bool { return p.In == "query" && p.Name == q }) {
+			h.t.Errorf("%s: sends undeclared query parameter %s", op.OperationID, q)
+		}
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	schema := h.doc.RequestSchema(op)
+	switch {
+	case schema == nil && len(body) > 0:
+		h.t.Errorf("%s: sends a body, but the operation takes none", op.OperationID)
+	case schema != nil:
+		if err := h.doc.ValidateJSON(schema, body); err != nil {
+			h.t.Errorf("%s: request body %s: %v", op.OperationID, body, err)
+		}
+	}
+
+	status := successStatus(op)
+	schema, _ = h.doc.ResponseSchema(op, status)
+	if schema == nil {
+		w.WriteHeader(status)
+		return
+	}
+	example, _ := json.Marshal(exampleOf(h.doc, schema))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(example)
+}
+
+// successStatus returns the lowest 2xx status op specifies.
+func successStatus(op *openapi.Operation) int {
+	var statuses []int
+	for code := range op.Responses {
+		var n int
+		if _, err := fmt.Sscan(code, &n); err == nil && n/100 == 2 {
+			statuses = append(statuses, n)
+		}
+	}
+	sort.Ints(statuses)
+	return statuses[0]
+}
+
+// exampleOf returns a minimal value conforming to s.
+func exampleOf(doc *openapi.Document, s *openapi.Schema) any {
+	s, _ = doc.Resolve(s)
+	if len(s.OneOf) > 0 {
+		return exampleOf(doc, s.OneOf[0])
+	}
+	switch s.Type {
+	case "object":
+		obj := map[string]any{}
+		for _, name := range s.Required {
+			obj[name] = exampleOf(doc, s.Properties.Get(name))
+		}
+		return obj
+	case "array":
+		return []any{exampleOf(doc, s.Items)}
+	case "integer", "number":
+		return 1
+	case "boolean":
+		return true
+	default:
+		return "AAAAA"
+	}
+}
+
+func TestClientConformsToSpec(t *testing.T) {
+	doc := loadSpec(t)
+	h := &contractHandler{t: t, doc: doc, called: map[string]bool{}}
+	c := newTestClient(t, h)
+	ctx := context.Background()
+
+	engineer := Engineer{Name: "Ann", Email: "ann@example.com"}
+	dev := Dev{Name: "Team", Engineers: []Engineer{{ID: "AAAAA"}}}
+	ops := Ops{Name: "Ops"}
+	devops := DevOps{Dev: []Dev{{ID: "AAAAA"}}, Ops: []Ops{{ID: "BBBBB"}}}
+
+	calls := map[string]func() error{
+		"GetEngineers":   func() error { _, err := c.GetEngineers(ctx); return err },
+		"CreateEngineer": func() error { _, err := c.CreateEngineer(ctx, engineer); return err },
+		"GetEngineer":    func() error { _, err := c.GetEngineer(ctx, "AAAAA"); return err },
+		"UpdateEngineer": func() error { _, err := c.UpdateEngineer(ctx, "AAAAA", engineer); return err },
+		"DeleteEngineer": func() error { return c.DeleteEngineer(ctx, "AAAAA") },
+		"GetDev":         func() error { _, err := c.GetDev(ctx); return err },
+		"GetDevByID":     func() error { _, err := c.GetDevByID(ctx, "AAAAA"); return err },
+		"CreateDev":      func() error { _, err := c.CreateDev(ctx, dev); return err },
+		"UpdateDev":      func() error { _, err := c.UpdateDev(ctx, "AAAAA", dev); return err },
+		"DeleteDev":      func() error { return c.DeleteDev(ctx, "AAAAA") },
+		"GetOps":         func() error { _, err := c.GetOps(ctx); return err },
+		"GetOpsByID":     func() error { _, err := c.GetOpsByID(ctx, "AAAAA"); return err },
+		"CreateOps":      func() error { _, err := c.CreateOps(ctx, ops); return err },
+		"UpdateOps":      func() error { _, err := c.UpdateOps(ctx, "AAAAA", ops); return err },
+		"DeleteOps":      func() error { return c.DeleteOps(ctx, "AAAAA") },
+		"GetDevOps":      func() error { _, err := c.GetDevOps(ctx); return err },
+		"GetDevOpsByID":  func() error { _, err := c.GetDevOpsByID(ctx, "AAAAA"); return err },
+		"CreateDevops":   func() error { _, err := c.CreateDevops(ctx, devops); return err },
+		"UpdateDevOps":   func() error { _, err := c.UpdateDevOps(ctx, "AAAAA", devops); return err },
+		"DeleteDevOps":   func() error { return c.DeleteDevOps(ctx, "AAAAA") },
+		"UpdateIfMatch": func() error {
+			_, err := c.Engineers.UpdateIfMatch(ctx, "AAAAA", engineer, `"v1"`)
+			return err
+		},
+		"DeleteIfMatch": func() error { return c.Devs.DeleteIfMatch(ctx, "AAAAA", `"v1"`) },
+		"Negotiate": func() error {
+			// The example offers no real version, but must decode.
+			if err := c.Negotiate(ctx); !errors.Is(err, ErrNoCommonAPIVersion) {
+				return err
+			}
+			return nil
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); err != nil {
+				t.Errorf("call against specified responses: %v", err)
+			}
+		})
+	}
+
+	// Every specified operation is one the client calls.
+	for template, item := range doc.Paths {
+		for method, op := range item.Operations() {
+			if !h.called[op.OperationID] {
+				t.Errorf("%s %s (%s) is specified but not called by any client method", method, template, op.OperationID)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CredentialProcessTimeout bounds a single run of a credential helper.
+const CredentialProcessTimeout = time.Minute
+
+// credentialProcessOutput is what a credential helper prints to stdout.
+type credentialProcessOutput struct {
+	Token string `json:"token"`
+	// ExpiresAt is when the token expires, in RFC 3339 format. Tokens
+	// without it are used until the API rejects them.
+	ExpiresAt string `json:"expires_at"`
+}
+
+// credentialProcessError reports a credential helper that failed or printed
+// something unusable. Running it again would fail the same way, so requests
+// are not retried.
+type credentialProcessError struct {
+	command string
+	err     error
+}
+
+func (e *credentialProcessError) Error() string {
+	return fmt.Sprintf("credential process %s %s", e.command, e.err)
+}
+
+func (e *credentialProcessError) Unwrap() error { return e.err }
+
+// WithCredentialProcess authenticates requests with bearer tokens printed
+// by a helper command, such as a client of a vault-backed token broker.
+// command is split into arguments like a shell would, honouring quotes and
+// backslashes, but is run without a shell. The helper must print a JSON
+// object such as {"token": "…", "expires_at": "2030-01-02T15:04:05Z"}.
+// Tokens are cached and the helper is run again shortly before they
+// expire, or when the API rejects them.
+func WithCredentialProcess(command string) Option {
+	return func(c *Client) error {
+		args, err := splitCommand(command)
+		if err != nil {
+			return fmt.Errorf("invalid credential process: %w", err)
+		}
+		if len(args) == 0 {
+			return errors.New("credential process must not be empty")
+		}
+		c.auth = &processAuth{args: args}
+		return nil
+	}
+}
+
+// processAuth obtains tokens from a credential helper.
+type processAuth struct {
+	args []string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (a *processAuth) authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *processAuth) invalidate() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+	return true
+}
+
+// accessToken returns the cached token, running the helper if it is
+// missing or about to expire.
+func (a *processAuth) accessToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && (a.expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(a.expiry)) {
+		return a.token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CredentialProcessTimeout)
+	defer cancel()
+	tflog.SubsystemDebug(logContext(ctx), LogSubsystem, "Running DOB credential process", map[string]interface{}{
+		"command": a.args[0],
+	})
+
+	fail := func(err error) (string, error) {
+		return "", &credentialProcessError{command: a.args[0], err: err}
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, a.args[0], a.args[1:]...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		if msg := lastLine(stderr.String()); msg != "" {
+			return fail(fmt.Errorf("failed: %w: %s", err, msg))
+		}
+		return fail(fmt.Errorf("failed: %w", err))
+	}
+
+	// The output holds a secret, so it is never quoted in errors.
+	var out credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return fail(errors.New("did not print a JSON object with token and expires_at"))
+	}
+	if out.Token == "" {
+		return fail(errors.New("printed no token"))
+	}
+	var expiry time.Time
+	if out.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, out.ExpiresAt)
+		if err != nil {
+			return fail(fmt.Errorf("printed expires_at %q, want an RFC 3339 time", out.ExpiresAt))
+		}
+		expiry = t
+	}
+
+	a.token, a.expiry = out.Token, expiry
+	return a.token, nil
+}
+
+// lastLine returns the last non-empty line of s, where helpers usually
+// explain a failure.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// splitCommand splits a command line into arguments. Single quotes keep
+// everything up to the next single quote, double quotes keep everything up
+// to the next unescaped double quote, and a backslash outside single
+// quotes escapes the following character.
+func splitCommand(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	switch {
+	case quote != 0:
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	case escaped:
+		return nil, errors.New("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeHelper writes a credential helper script that counts its runs in a
+// file and prints token tok-<run> expiring at the time given as argument.
+func writeHelper(t *testing.T) (script, runs string) {
+	t.Helper()
+	dir := t.TempDir()
+	script = filepath.Join(dir, "helper.sh")
+	runs = filepath.Join(dir, "runs")
+	err := os.WriteFile(script, []byte(`#!/bin/sh
+echo run >> "$1"
+n=$(wc -l < "$1" | tr -d ' ')
+printf '{"token": "tok-%s", "expires_at": "%s"}' "$n" "$2"
+`), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script, runs
+}
+
+func TestCredentialProcess(t *testing.T) {
+	script, runs := writeHelper(t)
+	soon := time.Now().Add(10 * time.Second).UTC().Format(time.RFC3339)
+	later := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	cases := map[string]struct {
+		expiresAt string
+		reject    string // token the API rejects
+		want      []string
+	}{
+		"cached until expiry":         {later, "", []string{"Bearer tok-1", "Bearer tok-1"}},
+		"refreshed before expiry":     {soon, "", []string{"Bearer tok-1", "Bearer tok-2"}},
+		"refreshed when rejected":     {later, "Bearer tok-1", []string{"Bearer tok-1", "Bearer tok-2", "Bearer tok-2"}},
+		"cached without an expiry at": {"", "", []string{"Bearer tok-1", "Bearer tok-1"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = os.Remove(runs)
+			var got []string
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth := r.Header.Get("Authorization")
+				got = append(got, auth)
+				if auth == tc.reject {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(`[]`))
+			}), WithCredentialProcess(script+" "+runs+" '"+tc.expiresAt+"'"))
+
+			for range 2 {
+				if _, err := c.GetEngineers(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Authorization headers = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCredentialProcessErrors(t *testing.T) {
+	cases := map[string]struct {
+		command string
+		want    string
+	}{
+		"failure":   {`sh -c 'echo "vault: permission denied" >&2; exit 3'`, "exit status 3: vault: permission denied"},
+		"not JSON":  {`echo s3cr3t`, "did not print a JSON object"},
+		"no token":  {`echo {}`, "printed no token"},
+		"bad time":  {`echo '{"token": "t", "expires_at": "tomorrow"}'`, `expires_at "tomorrow"`},
+		"not found": {`/nonexistent/dob-helper`, "no such file"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, http.NotFoundHandler(), WithCredentialProcess(tc.command))
+			_, err := c.GetEngineers(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tc.want)
+			}
+			if strings.Contains(err.Error(), "s3cr3t") {
+				t.Errorf("error %q leaks the helper's output", err)
+			}
+		})
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	for in, want := range map[string][]string{
+		`vault-broker token`:             {"vault-broker", "token"},
+		`  broker   --role  ci  `:        {"broker", "--role", "ci"},
+		`broker --name 'dob ci' "a \"b"`: {"broker", "--name", "dob ci", `a "b`},
+		`/opt/my\ tools/broker ''`:       {"/opt/my tools/broker", ""},
+		`'it\s'`:                         {`it\s`},
+	} {
+		got, err := splitCommand(in)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("splitCommand(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{`broker 'x`, `broker "x`, `broker \`} {
+		if _, err := splitCommand(in); err == nil {
+			t.Errorf("splitCommand(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestCredentialProcessFailureNotRetried(t *testing.T) {
+	runs := filepath.Join(t.TempDir(), "runs")
+	command := `sh -c 'echo run >> "$0"; exit 1' ` + runs
+	c := newTestClient(t, http.NotFoundHandler(), WithCredentialProcess(command))
+
+	if _, err := c.GetEngineers(context.Background()); err == nil {
+		t.Fatal("GetEngineers succeeded, want the helper's failure")
+	}
+	assertHealthProblem(t, c.CheckHealth(context.Background(), time.Second), HealthAuth)
+
+	out, err := os.ReadFile(runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(out), "run"); n != 2 {
+		t.Errorf("helper ran %d times, want once per request", n)
+	}
+}
@@ -1,105 +1,33 @@
 package client
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"strings"
+	"context"
+	"iter"
 )
 
-// GetDev - Returns list of dev groups
-func (c *Client) GetDev() ([]Dev, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dev", c.endpoint), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
+// ListDev iterates over all dev groups, fetching further pages as needed.
+func (c *Client) ListDev(ctx context.Context) iter.Seq2[Dev, error] {
+	return c.Devs.List(ctx)
+}
 
-	var devs []Dev
-	if err := json.Unmarshal(body, &devs); err != nil {
-		return nil, err
-	}
-	return devs, nil
+// GetDev - Returns list of dev groups
+func (c *Client) GetDev(ctx context.Context) ([]Dev, error) {
+	return collect(c.ListDev(ctx))
 }
 
 // GetDevByID - Returns a dev group by ID
-func (c *Client) GetDevByID(devID string) (*Dev, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dev/id/%s", c.endpoint, devID), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	var dev Dev
-	if err := json.Unmarshal(body, &dev); err != nil {
-		return nil, err
-	}
-	return &dev, nil
+func (c *Client) GetDevByID(ctx context.Context, devID string) (*Dev, error) {
+	return c.Devs.Get(ctx, devID)
 }
 
-func (c *Client) CreateDev(dev Dev) (*Dev, error) {
-	b, err := json.Marshal(dev)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/dev", c.endpoint), strings.NewReader(string(b)))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	var created Dev
-	if err := json.Unmarshal(body, &created); err != nil {
-		return nil, err
-	}
-	return &created, nil
+func (c *Client) CreateDev(ctx context.Context, dev Dev) (*Dev, error) {
+	return c.Devs.Create(ctx, dev)
 }
 
-func (c *Client) UpdateDev(devID string, dev Dev) (*Dev, error) {
-	rb, err := json.Marshal(dev)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/dev/%s", c.endpoint, devID), strings.NewReader(string(rb)))
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	updatedDev := Dev{}
-	err = json.Unmarshal(body, &updatedDev)
-	if err != nil {
-		return nil, err
-	}
-
-	return &updatedDev, nil
+func (c *Client) UpdateDev(ctx context.Context, devID string, dev Dev) (*Dev, error) {
+	return c.Devs.Update(ctx, devID, dev)
 }
 
-func (c *Client) DeleteDev(devID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/dev/%s", c.endpoint, devID), nil)
-	if err != nil {
-		return err
-	}
-
-	_, err = c.doRequest(req)
-	return err
-}
\ No newline at end of file
+func (c *Client) DeleteDev(ctx context.Context, devID string) error {
+	return c.Devs.Delete(ctx, devID)
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// SupportedAPIVersions are the API versions the client speaks, oldest
+// first.
+var SupportedAPIVersions = []string{"v1", "v2"}
+
+// CapabilityGroupDescriptions is the capability of API versions that keep
+// the description of dev and ops groups.
+const CapabilityGroupDescriptions = "group_descriptions"
+
+// ErrNoCommonAPIVersion is returned by Negotiate when the API offers none
+// of the versions the client supports.
+var ErrNoCommonAPIVersion = errors.New("no common DOB API version")
+
+// negotiatedAPI is the outcome of Negotiate.
+type negotiatedAPI struct {
+	version      string
+	capabilities map[string]bool
+}
+
+// WithDeferredNegotiation makes the client negotiate the API version
+// before its first API request rather than only when Negotiate is called,
+// so that setting it up needs no network access.
+func WithDeferredNegotiation() Option {
+	return func(c *Client) error {
+		c.deferNegotiation = true
+		return nil
+	}
+}
+
+// EnsureNegotiated negotiates the API version if the client defers
+// negotiation and has not negotiated yet. A failed negotiation is tried
+// again on the next call.
+func (c *Client) EnsureNegotiated(ctx context.Context) error {
+	if !c.deferNegotiation || c.api.Load() != nil {
+		return nil
+	}
+	c.negotiating.Lock()
+	defer c.negotiating.Unlock()
+	if c.api.Load() != nil {
+		return nil
+	}
+	return c.Negotiate(ctx)
+}
+
+// Negotiate asks the API which versions it offers and makes the client use
+// the highest one it also supports, with that version's capabilities. APIs
+// without a discovery endpoint predate versioning; the client keeps using
+// their unversioned routes without any capabilities.
+func (c *Client) Negotiate(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"/meta", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.do(req)
+	if IsNotFound(err) {
+		tflog.SubsystemDebug(logContext(ctx), LogSubsystem, "DOB API has no version discovery endpoint; using unversioned routes")
+		c.setAPIVersion(APIVersion{})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("discovering DOB API versions: %w", err)
+	}
+	var meta Meta
+	if err := json.Unmarshal(res.body, &meta); err != nil {
+		return fmt.Errorf("discovering DOB API versions: decoding %s: %w", req.URL, err)
+	}
+
+	var best *APIVersion
+	for i, v := range meta.Versions {
+		rank := slices.Index(SupportedAPIVersions, v.Version)
+		if rank >= 0 && (best == nil || rank > slices.Index(SupportedAPIVersions, best.Version)) {
+			best = &meta.Versions[i]
+		}
+	}
+	if best == nil {
+		offered := make([]string, 0, len(meta.Versions))
+		for _, v := range meta.Versions {
+			offered = append(offered, v.Version)
+		}
+		return fmt.Errorf("%w: the API offers %s, but this provider supports only %s; upgrade the provider",
+			ErrNoCommonAPIVersion, strings.Join(offered, ", "), strings.Join(SupportedAPIVersions, ", "))
+	}
+
+	tflog.SubsystemDebug(logContext(ctx), LogSubsystem, "Negotiated DOB API version", map[string]interface{}{
+		"api_version":  best.Version,
+		"capabilities": best.Capabilities,
+	})
+	c.setAPIVersion(*best)
+	return nil
+}
+
+func (c *Client) setAPIVersion(v APIVersion) {
+	api := &negotiatedAPI{version: v.Version, capabilities: map[string]bool{}}
+	for _, capability := range v.Capabilities {
+		api.capabilities[capability] = true
+	}
+	c.api.Store(api)
+}
+
+// Negotiated reports whether the client has negotiated the API version.
+// Until then it uses unversioned routes without any capabilities.
+func (c *Client) Negotiated() bool {
+	return c.api.Load() != nil
+}
+
+// APIVersion returns the negotiated API version, or "" if the client uses
+// unversioned routes.
+func (c *Client) APIVersion() string {
+	if api := c.api.Load(); api != nil {
+		return api.version
+	}
+	return ""
+}
+
+// Supports reports whether the negotiated API version has capability.
+func (c *Client) Supports(capability string) bool {
+	if api := c.api.Load(); api != nil {
+		return api.capabilities[capability]
+	}
+	return false
+}
+
+// url returns the URL of the API route path in the negotiated version,
+// negotiating first if the client defers it.
+func (c *Client) url(ctx context.Context, path string) (string, error) {
+	if err := c.EnsureNegotiated(ctx); err != nil {
+		return "", err
+	}
+	if version := c.APIVersion(); version != "" {
+		return c.endpoint + "/" + version + path, nil
+	}
+	return c.endpoint + path, nil
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// discoveryHandler serves meta as the /meta response, or 404 if it is
+// empty, and records the path of every other request.
+func discoveryHandler(meta string, paths *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/meta" {
+			if meta == "" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(meta))
+			return
+		}
+		*paths = append(*paths, r.URL.Path)
+		_, _ = w.Write([]byte(`[]`))
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name         string
+		meta         string
+		wantVersion  string
+		wantPath     string
+		descriptions bool
+	}{
+		{
+			name:     "legacy API without discovery",
+			wantPath: "/engineers",
+		},
+		{
+			name:        "only v1",
+			meta:        `{"versions":[{"version":"v1"}]}`,
+			wantVersion: "v1",
+			wantPath:    "/v1/engineers",
+		},
+		{
+			name:         "highest common version",
+			meta:         `{"versions":[{"version":"v1"},{"version":"v2","capabilities":["group_descriptions"]},{"version":"v9","capabilities":["teleport"]}]}`,
+			wantVersion:  "v2",
+			wantPath:     "/v2/engineers",
+			descriptions: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			c := newTestClient(t, discoveryHandler(tt.meta, &paths))
+			ctx := context.Background()
+
+			if err := c.Negotiate(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.APIVersion(); got != tt.wantVersion {
+				t.Errorf("APIVersion() = %q, want %q", got, tt.wantVersion)
+			}
+			if got := c.Supports(CapabilityGroupDescriptions); got != tt.descriptions {
+				t.Errorf("Supports(%s) = %v, want %v", CapabilityGroupDescriptions, got, tt.descriptions)
+			}
+			if _, err := c.GetEngineers(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if len(paths) != 1 || paths[0] != tt.wantPath {
+				t.Errorf("requested %v, want [%s]", paths, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestDeferredNegotiation(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, discoveryHandler(`{"versions":[{"version":"v2"}]}`, &paths), WithDeferredNegotiation())
+	if c.Negotiated() {
+		t.Fatal("negotiated before any request")
+	}
+
+	if _, err := c.GetEngineers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Negotiated() || c.APIVersion() != "v2" {
+		t.Errorf("after first request: negotiated %v, version %q, want v2", c.Negotiated(), c.APIVersion())
+	}
+	if len(paths) != 1 || paths[0] != "/v2/engineers" {
+		t.Errorf("requested %v, want [/v2/engineers]", paths)
+	}
+}
+
+func TestNegotiateNoCommonVersion(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, discoveryHandler(`{"versions":[{"version":"v3"}]}`, &paths))
+
+	err := c.Negotiate(context.Background())
+	if !errors.Is(err, ErrNoCommonAPIVersion) {
+		t.Fatalf("Negotiate() = %v, want ErrNoCommonAPIVersion", err)
+	}
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidateEndpoint checks that endpoint is a usable base URL for the DOB
+// API: an absolute http or https URL with a host, optionally followed by a
+// path prefix, and without query or fragment. A Unix socket endpoint names
+// the absolute path of the socket instead of a host, as in
+// unix:///run/dob.sock, followed by an optional prefix after a colon.
+func ValidateEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return errors.New("the endpoint is empty")
+	}
+	if socket, prefix, ok := socketEndpoint(endpoint); ok {
+		switch {
+		case !strings.HasPrefix(socket, "/") || socket == "/":
+			return fmt.Errorf("the endpoint %q does not name the absolute path of a socket; use a URL such as unix:///run/dob.sock", endpoint)
+		case prefix != "" && !strings.HasPrefix(prefix, "/"):
+			return fmt.Errorf("the endpoint %q has path prefix %q; start it with a slash, as in unix:///run/dob.sock:/dob", endpoint, prefix)
+		case strings.ContainsAny(prefix, "?#"):
+			return fmt.Errorf("the endpoint %q must not have a query or fragment", endpoint)
+		}
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("the endpoint is not a valid URL: %w", err)
+	}
+	switch {
+	case u.Scheme == "unix":
+		return fmt.Errorf("the endpoint %q is not a Unix socket URL such as unix:///run/dob.sock", endpoint)
+	case u.Scheme == "" || u.Host == "" && u.Opaque != "":
+		return fmt.Errorf("the endpoint %q has no scheme; use a URL such as https://%s", endpoint, endpoint)
+	case u.Scheme != "http" && u.Scheme != "https":
+		return fmt.Errorf("the endpoint %q has unsupported scheme %q; use http, https or unix", endpoint, u.Scheme)
+	case u.Host == "":
+		return fmt.Errorf("the endpoint %q has no host", endpoint)
+	case u.User != nil:
+		return fmt.Errorf("the endpoint %q contains credentials; configure them with token, username/password or oauth2 instead", u.Redacted())
+	case u.RawQuery != "" || u.Fragment != "":
+		return fmt.Errorf("the endpoint %q must not have a query or fragment", endpoint)
+	}
+	return nil
+}
@@ -1,111 +1,32 @@
 package client
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"strings"
+	"context"
+	"iter"
 )
 
-// GetEngineers - Returns list of engineers (no auth required)
-func (c *Client) GetEngineers() ([]Engineer, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/engineers", c.endpoint), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	engineers := []Engineer{}
-	err = json.Unmarshal(body, &engineers)
-	if err != nil {
-		return nil, err
-	}
-
-	return engineers, nil
+// ListEngineers iterates over all engineers, fetching further pages as needed.
+func (c *Client) ListEngineers(ctx context.Context) iter.Seq2[Engineer, error] {
+	return c.Engineers.List(ctx)
 }
 
-func (c *Client) CreateEngineer(engineer Engineer) (*Engineer, error) {
-	rb, err := json.Marshal(engineer)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/engineers", c.endpoint), strings.NewReader(string(rb)))
-	if err != nil {
-		return nil, err
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	newEngineer := Engineer{}
-	err = json.Unmarshal(body, &newEngineer)
-	if err != nil {
-		return nil, err
-	}
-
-	return &newEngineer, nil
+// GetEngineers - Returns list of engineers (no auth required)
+func (c *Client) GetEngineers(ctx context.Context) ([]Engineer, error) {
+	return collect(c.ListEngineers(ctx))
 }
 
-func (c *Client) GetEngineer(engineerID string) (*Engineer, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/engineers/id/%s", c.endpoint, engineerID), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	engineer := Engineer{}
-	if err := json.Unmarshal(body, &engineer); err != nil {
-		return nil, err
-	}
-
-	return &engineer, nil
+func (c *Client) CreateEngineer(ctx context.Context, engineer Engineer) (*Engineer, error) {
+	return c.Engineers.Create(ctx, engineer)
 }
 
-func (c *Client) UpdateEngineer(engineerID string, engineer Engineer) (*Engineer, error) {
-	rb, err := json.Marshal(engineer)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/engineers/id/%s", c.endpoint, engineerID), strings.NewReader(string(rb)))
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	updatedEngineer := Engineer{}
-	err = json.Unmarshal(body, &updatedEngineer)
-	if err != nil {
-		return nil, err
-	}
-
-	return &updatedEngineer, nil
+func (c *Client) GetEngineer(ctx context.Context, engineerID string) (*Engineer, error) {
+	return c.Engineers.Get(ctx, engineerID)
 }
 
-func (c *Client) DeleteEngineer(engineerID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/engineers/%s", c.endpoint, engineerID), nil)
-	if err != nil {
-		return err
-	}
+func (c *Client) UpdateEngineer(ctx context.Context, engineerID string, engineer Engineer) (*Engineer, error) {
+	return c.Engineers.Update(ctx, engineerID, engineer)
+}
 
-	_, err = c.doRequest(req)
-	return err
-}
\ No newline at end of file
+func (c *Client) DeleteEngineer(ctx context.Context, engineerID string) error {
+	return c.Engineers.Delete(ctx, engineerID)
+}
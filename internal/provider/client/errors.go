@@ -0,0 +1,138 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyLen bounds how much of an undecodable error body ends up in
+// an error message.
+const maxErrorBodyLen = 512
+
+// APIError is returned for every non-2xx response from the DOB API.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// RequestID is the backend's X-Request-ID for the failed request, if any.
+	RequestID string
+	// Body is the raw response body.
+	Body []byte
+	// Payload is the decoded error body, or nil if the body was not a JSON
+	// error document.
+	Payload *ErrorPayload
+}
+
+// newAPIError builds an APIError from a non-2xx response and its body.
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		RequestID:  res.Header.Get(RequestIDHeader),
+		Body:       body,
+	}
+
+	if e.RequestID == "" {
+		// The API did not echo the ID the client sent.
+		e.RequestID = req.Header.Get(RequestIDHeader)
+	}
+
+	var raw struct {
+		ErrorPayload
+		// Some handlers report the message under "error" instead.
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &raw); err == nil {
+		p := raw.ErrorPayload
+		if p.Message == "" {
+			p.Message = raw.Error
+		}
+		if p.Message != "" || len(p.Errors) > 0 {
+			e.Payload = &p
+		}
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+
+	switch {
+	case e.Payload != nil:
+		if e.Payload.Message != "" {
+			fmt.Fprintf(&b, ": %s", e.Payload.Message)
+		}
+		for _, fe := range e.Payload.Errors {
+			fmt.Fprintf(&b, "; %s: %s", fe.Field, fe.Message)
+		}
+	case len(e.Body) > 0:
+		body := strings.TrimSpace(string(e.Body))
+		if len(body) > maxErrorBodyLen {
+			body = body[:maxErrorBodyLen] + "..."
+		}
+		fmt.Fprintf(&b, ": %s", body)
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// FieldErrors returns the per-field validation errors reported by the API.
+func (e *APIError) FieldErrors() []FieldError {
+	if e.Payload == nil {
+		return nil
+	}
+	return e.Payload.Errors
+}
+
+// AsAPIError returns the APIError in err's chain, if there is one.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// HasStatus reports whether err is an APIError with the given status code.
+func HasStatus(err error, status int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether err is a 404 from the API.
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a 409 from the API.
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict)
+}
+
+// IsPreconditionFailed reports whether err is a 412 from the API, as
+// returned when an If-Match version is outdated.
+func IsPreconditionFailed(err error) bool {
+	return HasStatus(err, http.StatusPreconditionFailed)
+}
+
+// IsUnauthorized reports whether err is a 401 from the API or from the
+// OAuth2 token endpoint.
+func IsUnauthorized(err error) bool {
+	return HasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is a 403 from the API.
+func IsForbidden(err error) bool {
+	return HasStatus(err, http.StatusForbidden)
+}
+
+// IsValidation reports whether err is a 422 from the API.
+func IsValidation(err error) bool {
+	return HasStatus(err, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-123")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"validation failed","errors":[{"field":"email","message":"must be a valid email address"}]}`))
+	}), WithRetry(1, 0))
+
+	_, err := c.CreateEngineer(context.Background(), Engineer{Name: "Ann", Email: "nope"})
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Method != http.MethodPost || apiErr.RequestID != "req-123" {
+		t.Fatalf("unexpected error fields: %+v", apiErr)
+	}
+	if !IsValidation(err) || IsNotFound(err) || IsConflict(err) {
+		t.Fatalf("status helpers disagree with status %d", apiErr.StatusCode)
+	}
+	if fe := apiErr.FieldErrors(); len(fe) != 1 || fe[0].Field != "email" {
+		t.Fatalf("unexpected field errors: %+v", fe)
+	}
+	for _, want := range []string{"422", "validation failed", "email: must be a valid email address", "req-123"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
+
+func TestAPIErrorUndecodableBody(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such engineer", http.StatusNotFound)
+	}), WithRetry(1, 0))
+
+	err := c.DeleteEngineer(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	apiErr, _ := AsAPIError(err)
+	if apiErr.Payload != nil {
+		t.Fatalf("expected no payload for a plain text body, got %+v", apiErr.Payload)
+	}
+	if !strings.Contains(err.Error(), "no such engineer") {
+		t.Fatalf("error %q should include the raw body", err.Error())
+	}
+}
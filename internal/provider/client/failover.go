@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// EndpointCooldown is how long the client avoids an endpoint after a
+// connection error or a 5xx before trying it again.
+const EndpointCooldown = 30 * time.Second
+
+// writeStickiness is how long after a write the client keeps using the
+// endpoint that took it rather than failing back to a preferred one, so
+// that reading the write back does not depend on replication between
+// endpoints.
+const writeStickiness = time.Minute
+
+// safeMethods only read, so a request with one of them can be repeated on
+// another endpoint without risking a change being applied twice.
+var safeMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// WithFailoverEndpoints adds endpoints serving the same DOB API, such as
+// deployments in other regions, in order of preference after the endpoint
+// passed to NewClient. Requests go to one endpoint at a time; the client
+// switches to the next available one when it fails with a connection error
+// or a 5xx, repeating reads there right away, and returns to a preferred
+// endpoint once EndpointCooldown has passed and no write is in progress.
+func WithFailoverEndpoints(endpoints ...string) Option {
+	return func(c *Client) error {
+		if len(endpoints) == 0 {
+			return nil
+		}
+		if len(c.endpoints.urls) == 0 {
+			if err := c.endpoints.add(c.endpointName, c.endpoint); err != nil {
+				return err
+			}
+		}
+		for _, endpoint := range endpoints {
+			if err := c.endpoints.add(strings.TrimSuffix(endpoint, "/"), c.resolveEndpoint(endpoint)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// endpointPool tracks the health of the endpoints a client fails over
+// between and routes every request to one of them. It routes nothing when
+// there is at most one endpoint.
+type endpointPool struct {
+	names []string
+	urls  []*url.URL
+
+	mu        sync.Mutex
+	active    int
+	downUntil []time.Time
+	lastWrite time.Time
+}
+
+// add appends the endpoint configured as name, whose requests are built on
+// base.
+func (p *endpointPool) add(name, base string) error {
+	u, err := url.Parse(base)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid failover endpoint %q", name)
+	}
+	p.names = append(p.names, name)
+	p.urls = append(p.urls, u)
+	p.downUntil = append(p.downUntil, time.Time{})
+	return nil
+}
+
+// base returns endpoint i as configured.
+func (p *endpointPool) base(i int) string {
+	return p.names[i]
+}
+
+// endpointKey is the context key of an endpoint a request is pinned to.
+type endpointKey struct{}
+
+// withEndpoint returns ctx with its requests sent to endpoint i, whatever
+// its health.
+func withEndpoint(ctx context.Context, i int) context.Context {
+	return context.WithValue(ctx, endpointKey{}, i)
+}
+
+// route points req at the endpoint it should be sent to and returns its
+// index, or -1 if the client has a single endpoint.
+func (p *endpointPool) route(ctx context.Context, req *http.Request) int {
+	if len(p.urls) < 2 {
+		return -1
+	}
+	i, pinned := req.Context().Value(endpointKey{}).(int)
+	if !pinned {
+		i = p.pick(ctx, req.Method)
+	}
+
+	// The request targets the endpoint of its previous attempt, or the
+	// primary one it was built for.
+	current := p.indexOf(req.URL)
+	if current < 0 || current == i {
+		return i
+	}
+	rel := strings.TrimPrefix(req.URL.Path, p.urls[current].Path)
+	target := p.urls[i]
+	req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+	req.URL.Path, req.URL.RawPath = target.Path+rel, ""
+	req.Host = target.Host
+	return i
+}
+
+// pick returns the endpoint a request with method should go to, switching
+// away from the active endpoint while it is down and back to a preferred
+// one when that is possible without breaking a write sequence.
+func (p *endpointPool) pick(ctx context.Context, method string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+
+	next := p.active
+	switch {
+	case now.Before(p.downUntil[p.active]):
+		// Prefer the first endpoint that is up, or else the one that has
+		// been down the longest.
+		next = 0
+		for i := range p.urls {
+			if !now.Before(p.downUntil[i]) {
+				next = i
+				break
+			}
+			if p.downUntil[i].Before(p.downUntil[next]) {
+				next = i
+			}
+		}
+	case now.Sub(p.lastWrite) >= writeStickiness:
+		for i := range p.active {
+			if !now.Before(p.downUntil[i]) {
+				next = i
+				break
+			}
+		}
+	}
+	if next != p.active {
+		tflog.SubsystemInfo(ctx, LogSubsystem, "Switching DOB API endpoint", map[string]interface{}{
+			"from": p.base(p.active),
+			"to":   p.base(next),
+		})
+		p.active = next
+	}
+	if !safeMethods[method] {
+		p.lastWrite = now
+	}
+	return next
+}
+
+// report records whether endpoint i answered usably; a connection error or
+// a 5xx makes the client avoid it for EndpointCooldown.
+func (p *endpointPool) report(ctx context.Context, i int, healthy bool) {
+	if i < 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if healthy {
+		p.downUntil[i] = time.Time{}
+		return
+	}
+	if !time.Now().Before(p.downUntil[i]) {
+		tflog.SubsystemWarn(ctx, LogSubsystem, "DOB API endpoint unavailable", map[string]interface{}{
+			"endpoint": p.base(i),
+			"cooldown": EndpointCooldown.String(),
+		})
+	}
+	p.downUntil[i] = time.Now().Add(EndpointCooldown)
+}
+
+// canFailOver reports whether the endpoint u points at is down while
+// another endpoint is up, so a read that failed there can be repeated
+// elsewhere.
+func (p *endpointPool) canFailOver(u *url.URL) bool {
+	i := p.indexOf(u)
+	if i < 0 {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	if !now.Before(p.downUntil[i]) {
+		return false
+	}
+	for j := range p.urls {
+		if j != i && !now.Before(p.downUntil[j]) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexOf returns the endpoint u belongs to, or -1. Of endpoints on the
+// same host, the one with the longest matching path prefix wins.
+func (p *endpointPool) indexOf(u *url.URL) int {
+	found := -1
+	for i, e := range p.urls {
+		if e.Scheme != u.Scheme || e.Host != u.Host || !strings.HasPrefix(u.Path, e.Path) {
+			continue
+		}
+		if found < 0 || len(e.Path) > len(p.urls[found].Path) {
+			found = i
+		}
+	}
+	return found
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// region is a test API server counting the requests it receives.
+type region struct {
+	*httptest.Server
+	hits    atomic.Int32
+	failing atomic.Bool
+}
+
+// newRegion starts a region serving engineers below prefix.
+func newRegion(t *testing.T, prefix string) *region {
+	t.Helper()
+	r := &region{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.failing.Load():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case req.URL.Path != prefix+"/engineers":
+			http.NotFound(w, req)
+		case req.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"id":"E1","name":"Ann","email":"ann@example.com"}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// hits returns the number of requests each region received.
+func hits(regions ...*region) []int32 {
+	out := make([]int32, len(regions))
+	for i, r := range regions {
+		out[i] = r.hits.Load()
+	}
+	return out
+}
+
+func newFailoverClient(t *testing.T, primary string, failover ...string) *Client {
+	t.Helper()
+	c, err := NewClient(&primary, WithRetry(1, time.Millisecond), WithFailoverEndpoints(failover...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func assertHits(t *testing.T, got []int32, want ...int32) {
+	t.Helper()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("requests per endpoint = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFailoverReads(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_DOB_HTTP", "DEBUG")
+	eu, us := newRegion(t, ""), newRegion(t, "/dob")
+	eu.failing.Store(true)
+	c := newFailoverClient(t, eu.URL, us.URL+"/dob/")
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	// A failing read is repeated on the next endpoint even though retries
+	// are disabled, and later requests go there directly.
+	for range 2 {
+		if _, err := c.GetEngineers(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertHits(t, hits(eu, us), 1, 2)
+
+	for _, want := range []string{"DOB API endpoint unavailable", "Switching DOB API endpoint", `"endpoint":"` + us.URL + `/dob"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("logs do not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestFailoverOnConnectionError(t *testing.T) {
+	gone := httptest.NewServer(http.NotFoundHandler())
+	gone.Close()
+	us := newRegion(t, "")
+	c := newFailoverClient(t, gone.URL, us.URL)
+
+	if _, err := c.GetEngineers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assertHits(t, hits(us), 1)
+}
+
+func TestFailoverDoesNotRepeatWrites(t *testing.T) {
+	eu, us := newRegion(t, ""), newRegion(t, "")
+	eu.failing.Store(true)
+	c := newFailoverClient(t, eu.URL, us.URL)
+
+	// The write may have been applied despite the 503, so it is not sent
+	// to another endpoint, but the next request avoids the failed one.
+	if _, err := c.CreateEngineer(context.Background(), Engineer{Name: "Ann"}); !HasStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("err = %v, want the 503", err)
+	}
+	assertHits(t, hits(eu, us), 1, 0)
+
+	if _, err := c.CreateEngineer(context.Background(), Engineer{Name: "Ann"}); err != nil {
+		t.Fatal(err)
+	}
+	assertHits(t, hits(eu, us), 1, 1)
+}
+
+func TestFailoverStaysAfterWrites(t *testing.T) {
+	eu, us := newRegion(t, ""), newRegion(t, "")
+	eu.failing.Store(true)
+	c := newFailoverClient(t, eu.URL, us.URL)
+	ctx := context.Background()
+
+	if _, err := c.GetEngineers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateEngineer(ctx, Engineer{Name: "Ann"}); err != nil {
+		t.Fatal(err)
+	}
+	assertHits(t, hits(eu, us), 1, 2)
+
+	// The primary endpoint recovers, but the write just made is read back
+	// from the endpoint that took it.
+	eu.failing.Store(false)
+	c.endpoints.mu.Lock()
+	c.endpoints.downUntil[0] = time.Time{}
+	c.endpoints.mu.Unlock()
+	if _, err := c.GetEngineers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assertHits(t, hits(eu, us), 1, 3)
+
+	// Once the writes are over, the client returns to it.
+	c.endpoints.mu.Lock()
+	c.endpoints.lastWrite = time.Now().Add(-writeStickiness)
+	c.endpoints.mu.Unlock()
+	if _, err := c.GetEngineers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assertHits(t, hits(eu, us), 2, 3)
+}
+
+func TestCheckHealthFailover(t *testing.T) {
+	gone := httptest.NewServer(http.NotFoundHandler())
+	gone.Close()
+	us := newRegion(t, "")
+
+	c := newFailoverClient(t, gone.URL, us.URL)
+	if err := c.CheckHealth(context.Background(), time.Second); err != nil {
+		t.Fatalf("CheckHealth() = %v, want the failover endpoint to pass", err)
+	}
+	// The unreachable primary is skipped from then on.
+	if _, err := c.GetEngineers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	c = newFailoverClient(t, gone.URL, gone.URL+"/other")
+	err := c.CheckHealth(context.Background(), time.Second)
+	assertHealthProblem(t, err, HealthUnreachable)
+	if !strings.Contains(err.Error(), "/other") {
+		t.Errorf("error %q does not name every endpoint", err)
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultHealthCheckTimeout bounds the check of each endpoint in
+// CheckHealth.
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// HealthProblem classifies why CheckHealth failed.
+type HealthProblem int
+
+const (
+	// HealthUnreachable means no connection could be made to the host,
+	// for example because it refused it.
+	HealthUnreachable HealthProblem = iota + 1
+	// HealthDNS means the endpoint's host name did not resolve.
+	HealthDNS
+	// HealthTimeout means the API did not answer in time.
+	HealthTimeout
+	// HealthTLS means the TLS handshake failed.
+	HealthTLS
+	// HealthAuth means the API rejected the client's credentials.
+	HealthAuth
+	// HealthWrongService means something answered that is not a DOB API.
+	HealthWrongService
+	// HealthUnavailable means the API answered with a server error.
+	HealthUnavailable
+)
+
+// HealthError is returned by CheckHealth.
+type HealthError struct {
+	Problem HealthProblem
+	Err     error
+}
+
+func (e *HealthError) Error() string { return e.Err.Error() }
+
+func (e *HealthError) Unwrap() error { return e.Err }
+
+// CheckHealth makes sure the endpoint is a DOB API that accepts the
+// client's credentials. It sends a single HEAD request for the engineers
+// collection, which every API version serves unversioned, without retries
+// and giving up after timeout; Negotiate checks the versions the API
+// offers. With failover endpoints it succeeds as soon as one of them
+// passes, and the client avoids those it could not reach. Failures are
+// *HealthError.
+func (c *Client) CheckHealth(ctx context.Context, timeout time.Duration) error {
+	if len(c.endpoints.urls) == 0 {
+		return c.checkEndpoint(ctx, c.endpointName, timeout)
+	}
+
+	var (
+		problem HealthProblem
+		errs    []error
+	)
+	for i := range c.endpoints.urls {
+		err := c.checkEndpoint(withEndpoint(ctx, i), c.endpoints.base(i), timeout)
+		if err == nil {
+			return nil
+		}
+		var healthErr *HealthError
+		if errors.As(err, &healthErr) && problem == 0 {
+			problem = healthErr.Problem
+		}
+		errs = append(errs, err)
+	}
+	return &HealthError{problem, fmt.Errorf("no endpoint is available:\n%w", errors.Join(errs...))}
+}
+
+// checkEndpoint is CheckHealth for a single endpoint.
+func (c *Client) checkEndpoint(ctx context.Context, endpoint string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.endpoint+"/engineers", nil)
+	if err != nil {
+		return &HealthError{HealthUnreachable, err}
+	}
+	req.Header.Set("Accept", "application/json")
+	c.attribute(req)
+
+	_, status, header, err := c.send(logContext(ctx), req, 1)
+	var (
+		apiErr  *APIError
+		procErr *credentialProcessError
+	)
+	switch {
+	case err == nil:
+		if !isJSON(header.Get("Content-Type")) {
+			return &HealthError{HealthWrongService, fmt.Errorf("HEAD %s/engineers did not answer with JSON", endpoint)}
+		}
+		return nil
+	case status == 0 && errors.As(err, &apiErr):
+		// Only obtaining credentials, as from an oauth2 token endpoint,
+		// fails with an API error before the request is sent.
+		return &HealthError{HealthAuth, err}
+	case errors.As(err, &procErr):
+		return &HealthError{HealthAuth, err}
+	case status == http.StatusNotFound:
+		return &HealthError{HealthWrongService, fmt.Errorf("%s does not serve /engineers", endpoint)}
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return &HealthError{HealthAuth, err}
+	case status >= 500:
+		return &HealthError{HealthUnavailable, err}
+	case status != 0:
+		return &HealthError{HealthWrongService, err}
+	}
+	err = &requestError{requestID: req.Header.Get(RequestIDHeader), err: err}
+	return &HealthError{transportProblem(err), err}
+}
+
+// isJSON reports whether contentType is a JSON media type.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// transportProblem classifies a failure to get any response.
+func transportProblem(err error) HealthProblem {
+	var (
+		dnsErr      *net.DNSError
+		netErr      net.Error
+		verifyErr   *tls.CertificateVerificationError
+		alertErr    tls.AlertError
+		recordErr   tls.RecordHeaderError
+		unknownCA   x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		invalidErr  x509.CertificateInvalidError
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		return HealthDNS
+	case errors.As(err, &verifyErr), errors.As(err, &alertErr), errors.As(err, &recordErr),
+		errors.As(err, &unknownCA), errors.As(err, &hostnameErr), errors.As(err, &invalidErr),
+		strings.Contains(err.Error(), "server gave HTTP response to HTTPS client"):
+		return HealthTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return HealthTimeout
+	}
+	return HealthUnreachable
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem used for HTTP traffic. Its level
+// follows TF_LOG_PROVIDER and can be raised or lowered on its own with
+// TF_LOG_PROVIDER_DOB_HTTP.
+const LogSubsystem = "dob.http"
+
+// logMask replaces redacted values in logs.
+const logMask = "***"
+
+// DefaultMaskedLogFields are the JSON body fields whose values are always
+// masked in logs.
+var DefaultMaskedLogFields = []string{"email", "password", "token", "access_token", "client_secret"}
+
+// alwaysMaskedHeaders carry credentials and are never logged in clear text.
+var alwaysMaskedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// WithLogMaskedFields adds JSON body fields and headers whose values are
+// masked in logs to DefaultMaskedLogFields. Matching is case-insensitive.
+// Credential headers such as Authorization are masked regardless.
+func WithLogMaskedFields(fields []string) Option {
+	return func(c *Client) error {
+		for _, f := range fields {
+			c.maskedFields[strings.ToLower(f)] = true
+		}
+		return nil
+	}
+}
+
+func maskSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields)+len(alwaysMaskedHeaders))
+	for _, f := range fields {
+		set[strings.ToLower(f)] = true
+	}
+	for _, h := range alwaysMaskedHeaders {
+		set[strings.ToLower(h)] = true
+	}
+	return set
+}
+
+// logContext returns ctx with the HTTP logging subsystem attached.
+func logContext(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_DOB_HTTP"))
+}
+
+// logRequest logs an outgoing request; headers and body only at TRACE.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, attempt int) {
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+		"attempt":     attempt,
+		"request_id":  req.Header.Get(RequestIDHeader),
+	}
+	c.addEndpoint(fields, req)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending DOB API request", fields)
+
+	fields = map[string]interface{}{
+		"http_method":      req.Method,
+		"http_url":         req.URL.String(),
+		"http_req_headers": c.redactHeaders(req.Header),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			fields["http_req_body"] = c.redactBody(b)
+		}
+	}
+	tflog.SubsystemTrace(ctx, LogSubsystem, "DOB API request details", fields)
+}
+
+// logResponse logs a received response; headers and body only at TRACE.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, res *http.Response, body []byte) {
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+		"http_status": res.StatusCode,
+	}
+	if id := res.Header.Get(RequestIDHeader); id != "" {
+		fields["request_id"] = id
+	}
+	c.addEndpoint(fields, req)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received DOB API response", fields)
+
+	tflog.SubsystemTrace(ctx, LogSubsystem, "DOB API response details", map[string]interface{}{
+		"http_method":      req.Method,
+		"http_url":         req.URL.String(),
+		"http_status":      res.StatusCode,
+		"http_res_headers": c.redactHeaders(res.Header),
+		"http_res_body":    c.redactBody(body),
+	})
+}
+
+// addEndpoint names the endpoint req was sent to in fields when the client
+// fails over between several.
+func (c *Client) addEndpoint(fields map[string]interface{}, req *http.Request) {
+	if i := c.endpoints.indexOf(req.URL); i >= 0 {
+		fields["endpoint"] = c.endpoints.base(i)
+	}
+}
+
+// redactHeaders flattens h for logging with masked values replaced.
+func (c *Client) redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if c.maskedFields[strings.ToLower(k)] {
+			out[k] = logMask
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// redactBody masks the values of configured fields anywhere in a JSON
+// body. Other bodies, such as error pages of a proxy, can echo the same
+// values in any form, so only their size is logged.
+func (c *Client) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil {
+		if b, err := json.Marshal(c.redactValue(v)); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprintf("%s (%d bytes that are not JSON)", logMask, len(body))
+}
+
+func (c *Client) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if c.maskedFields[strings.ToLower(k)] {
+				v[k] = logMask
+				continue
+			}
+			v[k] = c.redactValue(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = c.redactValue(child)
+		}
+	}
+	return v
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRequestLoggingMasksSensitiveValues(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_DOB_HTTP", "TRACE")
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-1")
+		_, _ = w.Write([]byte(`{"id":"E1","name":"Ann","email":"ann@example.com"}`))
+	}), WithBearerToken("s3cret"))
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	if _, err := c.CreateEngineer(ctx, Engineer{Name: "Ann", Email: "ann@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := out.String()
+	for _, leaked := range []string{"ann@example.com", "s3cret"} {
+		if strings.Contains(logs, leaked) {
+			t.Errorf("logs leak %q:\n%s", leaked, logs)
+		}
+	}
+	for _, want := range []string{`"@module":"provider.dob.http"`, "DOB API request details", `"name\":\"Ann\"`, "req-1"} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs do not contain %q:\n%s", want, logs)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	c, err := NewClient(nil, WithLogMaskedFields([]string{"Secret"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Configured fields are masked in addition to the defaults.
+	got := c.redactBody([]byte(`[{"id":"a","secret":"x","nested":{"SECRET":1,"email":"ann@example.com"}}]`))
+	want := `[{"id":"a","nested":{"SECRET":"***","email":"***"},"secret":"***"}]`
+	if got != want {
+		t.Fatalf("redactBody = %s, want %s", got, want)
+	}
+
+	page := "<html><body>No engineer ann@example.com</body></html>"
+	if got := c.redactBody([]byte(page)); strings.Contains(got, "ann@example.com") {
+		t.Fatalf("non-JSON body leaked: %q", got)
+	}
+
+	headers := c.redactHeaders(http.Header{"Authorization": {"Bearer x"}, "Secret": {"y"}, "Accept": {"application/json"}})
+	if headers["Authorization"] != logMask || headers["Secret"] != logMask || headers["Accept"] != "application/json" {
+		t.Fatalf("unexpected header redaction: %v", headers)
+	}
+}
@@ -1,25 +1,5 @@
 package client
 
-type Engineer struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-}
-
-type Dev struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Engineers []Engineer `json:"engineers"`
-}
-
-type Ops struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Engineers []Engineer `json:"engineers"`
-}
-
-type DevOps struct{
-	ID    string `json:"id"`
-	Dev []Dev `json:"dev"`
-	Ops []Ops `json:"ops"`
-}
\ No newline at end of file
+// The API models in models_gen.go are generated from the DOB API's OpenAPI
+// document; edit api/openapi.json instead and regenerate them.
+//go:generate go run ../../../cmd/dob-modelgen -spec ../../../api/openapi.json -package client -o models_gen.go
@@ -0,0 +1,97 @@
+// Code generated by dob-modelgen from openapi.json; DO NOT EDIT.
+
+package client
+
+// Engineer is an engineer who can be a member of dev and ops groups.
+type Engineer struct {
+	// Five-character ID assigned by the API. Ignored on create.
+	ID string `json:"id"`
+
+	// Full name.
+	Name string `json:"name"`
+
+	// Email address.
+	Email string `json:"email"`
+}
+
+// Dev is a dev group. Members are written by ID and read back in full.
+type Dev struct {
+	// Five-character ID assigned by the API. Ignored on create.
+	ID string `json:"id"`
+
+	// Group name.
+	Name string `json:"name"`
+
+	// What the group is for. Only kept by API versions with the
+	// `group_descriptions` capability; others ignore it and never return it.
+	Description string `json:"description,omitempty"`
+
+	// Members of the group.
+	Engineers []Engineer `json:"engineers"`
+}
+
+// Ops is an ops group. Members are written by ID and read back in full.
+type Ops struct {
+	// Five-character ID assigned by the API. Ignored on create.
+	ID string `json:"id"`
+
+	// Group name.
+	Name string `json:"name"`
+
+	// What the group is for. Only kept by API versions with the
+	// `group_descriptions` capability; others ignore it and never return it.
+	Description string `json:"description,omitempty"`
+
+	// Members of the group.
+	Engineers []Engineer `json:"engineers"`
+}
+
+// DevOps is a pairing of dev and ops groups. Groups are written by ID and
+// read back in full.
+type DevOps struct {
+	// Five-character ID assigned by the API. Ignored on create.
+	ID string `json:"id"`
+
+	// Dev groups.
+	Dev []Dev `json:"dev"`
+
+	// Ops groups.
+	Ops []Ops `json:"ops"`
+}
+
+// Meta is the list of API versions a server offers.
+type Meta struct {
+	// Offered versions, in no particular order.
+	Versions []APIVersion `json:"versions"`
+}
+
+// APIVersion is an API version and the optional features it supports.
+type APIVersion struct {
+	// Version name, such as `v2`. Its routes are served under `/{version}`.
+	Version string `json:"version"`
+
+	// Optional features of the version, such as `group_descriptions`.
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// ErrorPayload is the JSON error document returned with every 4xx and 5xx
+// response.
+type ErrorPayload struct {
+	// Human-readable description of the error.
+	Message string `json:"message"`
+
+	// Machine-readable error code, such as `not_found`.
+	Code string `json:"code,omitempty"`
+
+	// Validation failures of single request fields.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a validation failure of a single request field.
+type FieldError struct {
+	// JSON name of the field.
+	Field string `json:"field"`
+
+	// What is wrong with the field.
+	Message string `json:"message"`
+}
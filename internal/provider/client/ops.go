@@ -1,105 +1,33 @@
-package client 
+package client
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"strings"
+	"context"
+	"iter"
 )
 
-// GetOps - Returns list of ops groups
-func (c *Client) GetOps() ([]Ops, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/op", c.endpoint), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
+// ListOps iterates over all ops groups, fetching further pages as needed.
+func (c *Client) ListOps(ctx context.Context) iter.Seq2[Ops, error] {
+	return c.Ops.List(ctx)
+}
 
-	var ops []Ops
-	if err := json.Unmarshal(body, &ops); err != nil {
-		return nil, err
-	}
-	return ops, nil
+// GetOps - Returns list of ops groups
+func (c *Client) GetOps(ctx context.Context) ([]Ops, error) {
+	return collect(c.ListOps(ctx))
 }
 
 // GetOpsByID - Returns a ops group by ID
-func (c *Client) GetOpsByID(opsID string) (*Ops, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/op/id/%s", c.endpoint, opsID), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	var ops Ops
-	if err := json.Unmarshal(body, &ops); err != nil {
-		return nil, err
-	}
-	return &ops, nil
+func (c *Client) GetOpsByID(ctx context.Context, opsID string) (*Ops, error) {
+	return c.Ops.Get(ctx, opsID)
 }
 
-func (c *Client) CreateOps(ops Ops) (*Ops, error) {
-	b, err := json.Marshal(ops)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/op/", c.endpoint), strings.NewReader(string(b)))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	var created Ops
-	if err := json.Unmarshal(body, &created); err != nil {
-		return nil, err
-	}
-	return &created, nil
+func (c *Client) CreateOps(ctx context.Context, ops Ops) (*Ops, error) {
+	return c.Ops.Create(ctx, ops)
 }
 
-func (c *Client) UpdateOps(opsID string, ops Ops) (*Ops, error) {
-	rb, err := json.Marshal(ops)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/op/%s", c.endpoint, opsID), strings.NewReader(string(rb)))
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	updatedOps := Ops{}
-	err = json.Unmarshal(body, &updatedOps)
-	if err != nil {
-		return nil, err
-	}
-
-	return &updatedOps, nil
+func (c *Client) UpdateOps(ctx context.Context, opsID string, ops Ops) (*Ops, error) {
+	return c.Ops.Update(ctx, opsID, ops)
 }
 
-func (c *Client) DeleteOps(opsID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/op/%s", c.endpoint, opsID), nil)
-	if err != nil {
-		return err
-	}
-
-	_, err = c.doRequest(req)
-	return err
-}
\ No newline at end of file
+func (c *Client) DeleteOps(ctx context.Context, opsID string) error {
+	return c.Ops.Delete(ctx, opsID)
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// page is the envelope of a paginated list response. Endpoints that have
+// not moved to pagination yet return a bare JSON array instead.
+type page[T any] struct {
+	Items []T `json:"items"`
+	// Next is an opaque cursor for the following page, or a URL to fetch
+	// it from. It is empty on the last page.
+	Next string `json:"next"`
+}
+
+// listAll iterates over every item of the collection at path, following
+// "next" cursors in the response body and rel="next" Link headers until
+// the last page. Iteration stops at the first error, which is yielded with
+// a zero item.
+func listAll[T any](ctx context.Context, c *Client, path string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		u, err := c.url(ctx, path)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		base, err := url.Parse(u)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		seen := map[string]bool{}
+		next := base.String()
+		for next != "" {
+			if seen[next] {
+				yield(zero, fmt.Errorf("GET %s: pagination loop detected", next))
+				return
+			}
+			seen[next] = true
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, nil)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			res, err := c.do(req)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, cursor, err := decodePage[T](res.body)
+			if err != nil {
+				yield(zero, fmt.Errorf("GET %s: decoding page: %w", next, err))
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next, err = nextPageURL(req.URL, base, cursor, res.header)
+			if err == nil && next != "" && !c.isAPIOrigin(next) {
+				err = fmt.Errorf("GET %s: next page %q is not on a DOB API endpoint", req.URL, next)
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// collect drains seq into a slice, stopping at the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// decodePage accepts both a bare JSON array and a page envelope.
+func decodePage[T any](body []byte) ([]T, string, error) {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []T
+		err := json.Unmarshal(trimmed, &items)
+		return items, "", err
+	}
+	var p page[T]
+	err := json.Unmarshal(body, &p)
+	return p.Items, p.Next, err
+}
+
+// nextPageURL resolves where the following page lives. A cursor in the body
+// wins over a Link header; an empty result means current was the last page.
+func nextPageURL(current, base *url.URL, cursor string, header http.Header) (string, error) {
+	if cursor != "" {
+		if strings.HasPrefix(cursor, "/") || strings.Contains(cursor, "://") {
+			u, err := current.Parse(cursor)
+			if err != nil {
+				return "", fmt.Errorf("invalid next page URL %q: %w", cursor, err)
+			}
+			return u.String(), nil
+		}
+		u := *base
+		q := u.Query()
+		q.Set("cursor", cursor)
+		u.RawQuery = q.Encode()
+		return u.String(), nil
+	}
+
+	if link := nextLink(header); link != "" {
+		u, err := current.Parse(link)
+		if err != nil {
+			return "", fmt.Errorf("invalid Link header URL %q: %w", link, err)
+		}
+		return u.String(), nil
+	}
+	return "", nil
+}
+
+// isAPIOrigin reports whether rawURL has the scheme and host of the
+// client's endpoint or one of its failover endpoints. Pages elsewhere are
+// not followed, since the request would carry the client's credentials.
+func (c *Client) isAPIOrigin(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	endpoints := c.endpoints.urls
+	if e, err := url.Parse(c.endpoint); err == nil {
+		endpoints = append([]*url.URL{e}, endpoints...)
+	}
+	for _, e := range endpoints {
+		if strings.EqualFold(e.Scheme, u.Scheme) && strings.EqualFold(e.Host, u.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextLink returns the target of the rel="next" entry of a Link header.
+func nextLink(header http.Header) string {
+	for _, v := range header.Values("Link") {
+		for _, link := range strings.Split(v, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok {
+				continue
+			}
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(key, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
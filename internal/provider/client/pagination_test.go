@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestListFollowsCursors(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"items":[{"id":"E1"},{"id":"E2"}],"next":"c2"}`,
+		"c2": `{"items":[{"id":"E3"}],"next":"c3"}`,
+		"c3": `{"items":[],"next":""}`,
+	}
+	var requested []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		requested = append(requested, r.URL.Path+"?"+cursor)
+		_, _ = w.Write([]byte(pages[cursor]))
+	}))
+
+	engineers, err := c.GetEngineers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(engineers); got != "E1,E2,E3" {
+		t.Fatalf("got engineers %s", got)
+	}
+	if got := strings.Join(requested, " "); got != "/engineers? /engineers?c2 /engineers?c3" {
+		t.Fatalf("unexpected requests %s", got)
+	}
+}
+
+func TestListFollowsLinkHeaders(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `</op?page=2>; rel="next", </op?page=9>; rel="last"`)
+			_, _ = w.Write([]byte(`[{"id":"O1"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"id":"O2"}]`))
+		default:
+			t.Errorf("unexpected page %s", r.URL)
+		}
+	}))
+
+	var got []string
+	for ops, err := range c.ListOps(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, ops.ID)
+	}
+	if strings.Join(got, ",") != "O1,O2" {
+		t.Fatalf("got ops %v", got)
+	}
+}
+
+func TestListStopsWhenConsumerBreaks(t *testing.T) {
+	var calls int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"items":[{"id":"D%d"}],"next":"p%d"}`, calls, calls+1)
+	}))
+
+	for range c.ListDev(context.Background()) {
+		break
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single page request, got %d", calls)
+	}
+}
+
+func TestListDetectsCursorLoops(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"id":"X"}],"next":"same"}`))
+	}))
+
+	if _, err := c.GetDevOps(context.Background()); err == nil || !strings.Contains(err.Error(), "loop") {
+		t.Fatalf("expected a pagination loop error, got %v", err)
+	}
+}
+
+func TestListStaysOnAPIOrigin(t *testing.T) {
+	var leaked []string
+	other := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = append(leaked, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+
+	for name, link := range map[string]func(w http.ResponseWriter){
+		"cursor": func(w http.ResponseWriter) {
+			fmt.Fprintf(w, `{"items":[{"id":"E1"}],"next":%q}`, other.endpoint+"/engineers?page=2")
+		},
+		"Link header": func(w http.ResponseWriter) {
+			w.Header().Set("Link", "<"+other.endpoint+`/engineers?page=2>; rel="next"`)
+			_, _ = w.Write([]byte(`[{"id":"E1"}]`))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				link(w)
+			}), WithBearerToken("s3cret"))
+
+			if _, err := c.GetEngineers(context.Background()); err == nil || !strings.Contains(err.Error(), "not on a DOB API endpoint") {
+				t.Fatalf("expected the next page to be refused, got %v", err)
+			}
+			if len(leaked) > 0 {
+				t.Fatalf("other server received requests with Authorization %q", leaked)
+			}
+		})
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	h := http.Header{}
+	h.Add("Link", `<https://api.example.com/engineers?cursor=abc>; rel="prev next"`)
+	if got := nextLink(h); got != "https://api.example.com/engineers?cursor=abc" {
+		t.Fatalf("nextLink = %q", got)
+	}
+
+	h = http.Header{"Link": {`<https://api.example.com/engineers?page=1>; rel="first"`}}
+	if got := nextLink(h); got != "" {
+		t.Fatalf("nextLink = %q, want none", got)
+	}
+
+	current, _ := url.Parse("https://api.example.com/engineers?cursor=a")
+	base, _ := url.Parse("https://api.example.com/engineers")
+	if got, _ := nextPageURL(current, base, "/engineers?after=5", nil); got != "https://api.example.com/engineers?after=5" {
+		t.Fatalf("relative next URL resolved to %q", got)
+	}
+}
+
+func ids(engineers []Engineer) string {
+	out := make([]string, 0, len(engineers))
+	for _, e := range engineers {
+		out = append(out, e.ID)
+	}
+	return strings.Join(out, ",")
+}
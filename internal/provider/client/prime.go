@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WithPrimedReads serves reads of single objects from one list request per
+// collection, made on the first read, so refreshing many objects costs one
+// request per page of each collection rather than one per object. Objects
+// written through the client, and objects missing from the list, are read
+// individually as usual.
+//
+// Objects read from the list carry no version (see
+// Collection.GetVersioned); callers keep the version of their last
+// individual read for conditional writes.
+func WithPrimedReads() Option {
+	return func(c *Client) error {
+		c.primeReads = true
+		return nil
+	}
+}
+
+// primeCache holds the objects of a collection's list, keyed by ID.
+type primeCache struct {
+	mu sync.Mutex
+	// ready is closed once items is loaded; it is nil until the first
+	// lookup starts loading.
+	ready chan struct{}
+	// items is nil if loading failed.
+	items map[string]json.RawMessage
+	// written holds the IDs written since loading started; the list may
+	// predate those writes.
+	written map[string]bool
+}
+
+// lookup returns the listed object with the given ID, loading the list on
+// first use. Callers that find nothing read the object individually.
+func (p *primeCache) lookup(ctx context.Context, c *Client, path, id string) (json.RawMessage, bool) {
+	p.mu.Lock()
+	ready := p.ready
+	if ready == nil {
+		p.ready = make(chan struct{})
+		p.mu.Unlock()
+
+		items := p.load(ctx, c, path)
+		p.mu.Lock()
+		p.items = items
+		close(p.ready)
+	} else {
+		p.mu.Unlock()
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil, false
+		}
+		p.mu.Lock()
+	}
+	defer p.mu.Unlock()
+
+	if p.written[id] {
+		return nil, false
+	}
+	raw, ok := p.items[id]
+	return raw, ok
+}
+
+// load lists the collection at path. Failing to do so only costs the
+// individual reads, so errors are logged rather than returned.
+func (p *primeCache) load(ctx context.Context, c *Client, path string) map[string]json.RawMessage {
+	items := map[string]json.RawMessage{}
+	for raw, err := range listAll[json.RawMessage](ctx, c, path) {
+		if err != nil {
+			tflog.SubsystemDebug(logContext(ctx), LogSubsystem, "Not priming DOB API reads", map[string]interface{}{
+				"http_path": path,
+				"error":     err.Error(),
+			})
+			return nil
+		}
+		var obj struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(raw, &obj) == nil && obj.ID != "" {
+			items[obj.ID] = raw
+		}
+	}
+	return items
+}
+
+// forget makes reads of id bypass the list from now on.
+func (p *primeCache) forget(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.written == nil {
+		p.written = map[string]bool{}
+	}
+	p.written[id] = true
+}
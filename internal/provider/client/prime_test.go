@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// primeHandler lists engineers A and B and counts requests by path.
+func primeHandler(mu *sync.Mutex, hits map[string]int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		switch {
+		case r.Method != http.MethodGet:
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/engineers":
+			_, _ = w.Write([]byte(`{"items":[{"id":"A","name":"Ann"},{"id":"B","name":"Bob"}]}`))
+		default:
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			_, _ = w.Write([]byte(`{"id":"` + id + `","name":"fresh"}`))
+		}
+	})
+}
+
+func TestPrimedReads(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	c := newTestClient(t, primeHandler(&mu, hits), WithPrimedReads())
+	ctx := context.Background()
+
+	for _, id := range []string{"A", "B", "A"} {
+		e, version, err := c.Engineers.GetVersioned(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.ID != id || e.Name == "fresh" || version != "" {
+			t.Errorf("GetVersioned(%s) = %+v, %q, want listed object without version", id, e, version)
+		}
+	}
+
+	// Objects missing from the list or written since are read individually.
+	if e, err := c.GetEngineer(ctx, "C"); err != nil || e.Name != "fresh" {
+		t.Errorf("GetEngineer(C) = %+v, %v", e, err)
+	}
+	if _, err := c.UpdateEngineer(ctx, "A", Engineer{Name: "Anne"}); err != nil {
+		t.Fatal(err)
+	}
+	if e, err := c.GetEngineer(ctx, "A"); err != nil || e.Name != "fresh" {
+		t.Errorf("GetEngineer(A) after update = %+v, %v", e, err)
+	}
+
+	want := map[string]int{
+		"GET /engineers":      1,
+		"GET /engineers/id/C": 1,
+		"PUT /engineers/A":    1,
+		"GET /engineers/id/A": 1,
+	}
+	for k, n := range want {
+		if hits[k] != n {
+			t.Errorf("%s: %d requests, want %d (all: %v)", k, hits[k], n, hits)
+		}
+	}
+	if len(hits) != len(want) {
+		t.Errorf("requests = %v, want %v", hits, want)
+	}
+}
+
+func TestPrimedReadsFallBackWhenListFails(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/engineers" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		primeHandler(&mu, hits).ServeHTTP(w, r)
+	}), WithPrimedReads())
+
+	for range 2 {
+		if e, err := c.GetEngineer(context.Background(), "A"); err != nil || e.Name != "fresh" {
+			t.Fatalf("GetEngineer = %+v, %v", e, err)
+		}
+	}
+	if n := hits["GET /engineers/id/A"]; n != 2 {
+		t.Errorf("individual reads = %d, want 2", n)
+	}
+}
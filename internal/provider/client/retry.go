@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts made for a retryable
+	// request when the provider does not configure one.
+	DefaultMaxAttempts = 4
+
+	// DefaultMaxBackoff caps the delay between two attempts.
+	DefaultMaxBackoff = 30 * time.Second
+
+	// baseBackoff is the delay ceiling for the first retry; it doubles on
+	// every subsequent attempt until it reaches the configured maximum.
+	baseBackoff = 500 * time.Millisecond
+)
+
+// retryPolicy decides whether and when a failed request is attempted again.
+type retryPolicy struct {
+	maxAttempts int
+	maxBackoff  time.Duration
+}
+
+// WithRetry configures how many times a request is attempted in total and
+// the longest delay the client waits between two attempts. A maxAttempts of
+// 1 disables retries.
+func WithRetry(maxAttempts int, maxBackoff time.Duration) Option {
+	return func(c *Client) error {
+		if maxAttempts < 1 {
+			return errors.New("retry max attempts must be at least 1")
+		}
+		if maxBackoff < 0 {
+			return errors.New("retry max backoff must not be negative")
+		}
+		c.retry = retryPolicy{maxAttempts: maxAttempts, maxBackoff: maxBackoff}
+		return nil
+	}
+}
+
+// idempotentMethods are safe to replay after a transport error or a 5xx,
+// because repeating them cannot create duplicate objects on the backend.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// shouldRetry reports whether a request that ended with the given response
+// status (0 when no response was received) or error may be attempted again.
+// A 429 means the backend rejected the request without processing it, so it
+// is retried for every verb; transport errors and 502/503/504 only for
+// idempotent ones. Other API errors such as 404 are never retried, and
+// neither is a 500: unlike the gateway statuses it reports a failure inside
+// the DOB API itself, which repeating the request rarely avoids, and the
+// backend may have applied part of the request before failing.
+func (p retryPolicy) shouldRetry(ctx context.Context, method string, status int, err error) bool {
+	var procErr *credentialProcessError
+	if ctx.Err() != nil || errors.As(err, &procErr) {
+		return false
+	}
+	if apiErr, ok := AsAPIError(err); ok && status == 0 {
+		// Obtaining credentials failed with the status of the token
+		// endpoint before the request was sent.
+		status = apiErr.StatusCode
+	}
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	if !idempotentMethods[method] {
+		return false
+	}
+	switch {
+	case status == 0 || status/100 == 2:
+		// No usable response: a transport error or a truncated body.
+		return err != nil
+	case status == http.StatusBadGateway, status == http.StatusServiceUnavailable, status == http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header from the backend takes precedence over the exponential schedule;
+// either way the delay never exceeds the configured maximum.
+func (p retryPolicy) backoff(attempt int, header http.Header) time.Duration {
+	if d, ok := parseRetryAfter(header, time.Now()); ok {
+		return min(d, p.maxBackoff)
+	}
+
+	ceiling := p.maxBackoff
+	if shift := attempt - 1; shift < 30 {
+		ceiling = min(baseBackoff<<shift, p.maxBackoff)
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	// Full jitter spreads out clients that failed at the same moment.
+	return rand.N(ceiling) + 1
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, h http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(&srv.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestDoRequestRetriesTransientStatuses(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`[{"id":"a","name":"Ann","email":"ann@example.com"}]`))
+		}
+	}), WithRetry(3, 10*time.Millisecond))
+
+	engineers, err := c.GetEngineers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(engineers) != 1 || calls.Load() != 3 {
+		t.Fatalf("got %d engineers after %d calls", len(engineers), calls.Load())
+	}
+}
+
+func TestDoRequestStopsAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}), WithRetry(2, time.Millisecond))
+
+	if _, err := c.GetDev(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestDoRequestDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}), WithRetry(4, time.Millisecond))
+
+	if _, err := c.GetDevByID(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("404 should not be retried, got %d attempts", got)
+	}
+}
+
+func TestDoRequestReplaysBodyOnRetry(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		var buf [512]byte
+		n, _ := r.Body.Read(buf[:])
+		_, _ = w.Write(buf[:n])
+	}), WithRetry(2, time.Millisecond))
+
+	created, err := c.CreateEngineer(context.Background(), Engineer{Name: "Ann", Email: "ann@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Email != "ann@example.com" {
+		t.Fatalf("retried request lost its body, got %+v", created)
+	}
+}
+
+func TestDoRequestDoesNotRetryNonIdempotentServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}), WithRetry(4, time.Millisecond))
+
+	if _, err := c.CreateDev(context.Background(), Dev{Name: "team"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("POST should not be retried on 503, got %d attempts", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		"seconds":  {"7", 7 * time.Second, true},
+		"date":     {now.Add(3 * time.Second).Format(http.TimeFormat), 3 * time.Second, true},
+		"past":     {now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		"missing":  {"", 0, false},
+		"garbage":  {"soon", 0, false},
+		"negative": {"-1", 0, false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := http.Header{}
+			if tc.value != "" {
+				h.Set("Retry-After", tc.value)
+			}
+			got, ok := parseRetryAfter(h, now)
+			if got != tc.want || ok != tc.ok {
+				t.Fatalf("parseRetryAfter(%q) = %s, %t; want %s, %t", tc.value, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
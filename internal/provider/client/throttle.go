@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaxConcurrentRequests caps in-flight requests when the provider
+// does not configure a limit. It matches Terraform's default parallelism.
+const DefaultMaxConcurrentRequests = 10
+
+// maxRateLimitWait bounds how long the throttle waits on a reset time
+// reported by the API, guarding against clock skew or bogus headers.
+const maxRateLimitWait = time.Minute
+
+// WithMaxConcurrentRequests limits how many requests the client keeps in
+// flight at once. Further requests wait for a free slot.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Client) error {
+		if n < 1 {
+			return errors.New("max concurrent requests must be at least 1")
+		}
+		c.throttle = newThrottle(n)
+		return nil
+	}
+}
+
+// throttle caps in-flight requests and spaces them out according to the
+// X-RateLimit-Remaining and X-RateLimit-Reset headers of earlier responses,
+// so the client slows down before the API starts answering 429.
+type throttle struct {
+	slots chan struct{}
+
+	mu sync.Mutex
+	// next is the earliest time the next request may be sent.
+	next time.Time
+	// spacing is the minimum interval between two requests while the
+	// remaining rate-limit budget is low.
+	spacing time.Duration
+}
+
+func newThrottle(n int) *throttle {
+	return &throttle{slots: make(chan struct{}, n)}
+}
+
+// acquire blocks until a request may be sent and returns a function that
+// releases its slot.
+func (t *throttle) acquire(ctx context.Context) (func(), error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	release := func() { <-t.slots }
+
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	wait := start.Sub(now)
+	// Reserve a spacing interval for this request so concurrent callers
+	// queue up behind it instead of firing together.
+	t.next = start.Add(t.spacing)
+	t.mu.Unlock()
+
+	if wait > 0 {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Throttling DOB API request to respect rate limit", map[string]interface{}{
+			"wait": wait.String(),
+		})
+		if err := sleep(ctx, wait); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// observe updates the pacing from a response's rate-limit headers.
+func (t *throttle) observe(header http.Header, now time.Time) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now)
+	if !ok {
+		return
+	}
+	window := min(reset.Sub(now), maxRateLimitWait)
+	if window <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if remaining <= 0 {
+		// The budget is spent: hold everything until the window resets.
+		t.spacing = 0
+		t.next = now.Add(window)
+		return
+	}
+	// Spread the remaining budget over the rest of the window, keeping a
+	// slot per in-flight request in reserve.
+	budget := remaining - cap(t.slots)
+	if budget > 0 {
+		t.spacing = 0
+		return
+	}
+	t.spacing = window / time.Duration(remaining)
+	if next := now.Add(t.spacing); next.After(t.next) {
+		t.next = next
+	}
+}
+
+// parseRateLimitReset reads X-RateLimit-Reset, which APIs send either as a
+// Unix timestamp or as a number of seconds until the window resets.
+func parseRateLimitReset(v string, now time.Time) (time.Time, bool) {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+	// Anything that looks like a plausible epoch timestamp is one; smaller
+	// values are relative.
+	if n > 1_000_000_000 {
+		return time.Unix(n, 0), true
+	}
+	return now.Add(time.Duration(n) * time.Second), true
+}
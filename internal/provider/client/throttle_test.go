@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":"E1"}`))
+	}), WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.CreateEngineer(context.Background(), Engineer{Name: "Ann"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("expected at most 2 requests in flight, saw %d", got)
+	}
+}
+
+func TestThrottleWaitsForExhaustedBudget(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1")
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+
+	ctx := context.Background()
+	if _, err := c.GetEngineers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := c.GetEngineers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Fatalf("second request was sent after %s, before the rate-limit window reset", elapsed)
+	}
+}
+
+func TestThrottleObserve(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	header := func(remaining int, reset string) http.Header {
+		h := http.Header{}
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("X-RateLimit-Reset", reset)
+		return h
+	}
+
+	th := newThrottle(4)
+	th.observe(header(100, "60"), now)
+	if th.spacing != 0 || th.next.After(now) {
+		t.Fatalf("plenty of budget should not throttle, got spacing %s next %s", th.spacing, th.next)
+	}
+
+	th.observe(header(2, strconv.FormatInt(now.Add(10*time.Second).Unix(), 10)), now)
+	if th.spacing != 5*time.Second {
+		t.Fatalf("expected the remaining budget spread over the window, got spacing %s", th.spacing)
+	}
+
+	th.observe(header(0, "30"), now)
+	if !th.next.Equal(now.Add(30 * time.Second)) {
+		t.Fatalf("expected requests held until reset, next = %s", th.next)
+	}
+
+	// Missing or malformed headers leave the pacing untouched.
+	before := th.next
+	th.observe(http.Header{}, now)
+	th.observe(header(0, "soon"), now)
+	if !th.next.Equal(before) {
+		t.Fatal("malformed headers changed the pacing")
+	}
+}
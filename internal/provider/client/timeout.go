@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// DefaultRequestTimeout bounds a single attempt of an API request when the
+// provider does not configure a timeout.
+const DefaultRequestTimeout = 10 * time.Second
+
+// WithRequestTimeout bounds every attempt of an API request, from
+// connecting to reading the response body, by timeout. The operation as a
+// whole, including retries, is bounded by the deadline of the request's
+// context instead.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return errors.New("request timeout must be positive")
+		}
+		c.HTTPClient.Timeout = timeout
+		return nil
+	}
+}
+
+// IsRequestTimeout reports whether err is an attempt that ran out of the
+// request timeout, as opposed to the deadline of the request's context.
+func IsRequestTimeout(err error) bool {
+	// send returns the context's error directly once it is done, so only
+	// the HTTP client's own timeout surfaces as a *url.Error.
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && urlErr.Timeout()
+}
+
+// outlastsDeadline reports whether waiting for wait would end after the
+// deadline of ctx, so that a retry could no longer complete.
+func outlastsDeadline(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Now().Add(wait).After(deadline)
+}
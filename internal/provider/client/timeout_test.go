@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRequestTimeout(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}), WithRequestTimeout(50*time.Millisecond), WithRetry(2, time.Millisecond))
+
+	// The slow first attempt times out on its own and is retried.
+	if _, err := c.GetEngineers(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+
+	calls.Store(0)
+	c.retry.maxAttempts = 1
+	_, err := c.GetEngineers(context.Background())
+	if !IsRequestTimeout(err) {
+		t.Fatalf("err = %v, want a request timeout", err)
+	}
+
+	if err := WithRequestTimeout(0)(c); err == nil {
+		t.Error("WithRequestTimeout(0) succeeded, want error")
+	}
+}
+
+func TestDoRequestStopsRetryingAtDeadline(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}), WithRetry(4, 10*time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetDevByID(ctx, "a")
+
+	// The 503 is reported right away instead of waiting 5s past the
+	// deadline for a retry that could not complete.
+	if !HasStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("err = %v, want the 503", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("gave up after %s, want immediately", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
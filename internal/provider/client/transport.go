@@ -0,0 +1,86 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TLSConfig holds the TLS settings for connections to the DOB API.
+type TLSConfig struct {
+	// CACertPEM holds PEM-encoded CA certificates trusted in addition to
+	// the system roots.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM hold a PEM-encoded client certificate
+	// and key presented for mutual TLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// InsecureSkipVerify disables server certificate verification. Only
+	// meant for local development.
+	InsecureSkipVerify bool
+}
+
+// newTransport returns the base transport used by NewClient.
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	return t
+}
+
+// WithTLS applies cfg to the client's transport.
+func WithTLS(cfg TLSConfig) Option {
+	return func(c *Client) error {
+		tlsConfig := c.transport.TLSClientConfig
+
+		if len(cfg.CACertPEM) > 0 {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+				return errors.New("no valid PEM certificates found in CA bundle")
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		switch {
+		case len(cfg.ClientCertPEM) > 0 && len(cfg.ClientKeyPEM) > 0:
+			cert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+			if err != nil {
+				return fmt.Errorf("loading client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		case len(cfg.ClientCertPEM) > 0 || len(cfg.ClientKeyPEM) > 0:
+			return errors.New("client certificate and client key must be configured together")
+		}
+
+		tlsConfig.InsecureSkipVerify = cfg.InsecureSkipVerify
+		return nil
+	}
+}
+
+// WithProxy sends all API requests through the given HTTP(S) proxy instead
+// of the one selected by the HTTP_PROXY/HTTPS_PROXY environment variables.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q", proxyURL)
+		}
+		c.transport.Proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithTransportWrapper wraps the round tripper the client sends requests
+// through, for example to inject faults in tests. Wrappers apply in option
+// order, so the last one sees requests first.
+func WithTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *Client) error {
+		c.HTTPClient.Transport = wrap(c.HTTPClient.Transport)
+		return nil
+	}
+}
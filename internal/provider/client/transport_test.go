@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`[]`))
+}
+
+func serverCAPEM(srv *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+// selfSignedPair returns a PEM-encoded self-signed certificate and key.
+func selfSignedPair(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestWithTLSCustomCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(okHandler))
+	defer srv.Close()
+
+	untrusted, err := NewClient(&srv.URL, WithRetry(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := untrusted.GetEngineers(context.Background()); err == nil {
+		t.Fatal("expected certificate verification to fail without the CA")
+	}
+
+	trusted, err := NewClient(&srv.URL, WithTLS(TLSConfig{CACertPEM: serverCAPEM(srv)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := trusted.GetEngineers(context.Background()); err != nil {
+		t.Fatalf("expected request to succeed with the CA, got %v", err)
+	}
+
+	insecure, err := NewClient(&srv.URL, WithTLS(TLSConfig{InsecureSkipVerify: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insecure.GetEngineers(context.Background()); err != nil {
+		t.Fatalf("expected request to succeed without verification, got %v", err)
+	}
+}
+
+func TestWithTLSClientCertificate(t *testing.T) {
+	certPEM, keyPEM := selfSignedPair(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(okHandler))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	defer srv.Close()
+
+	without, err := NewClient(&srv.URL, WithRetry(1, 0), WithTLS(TLSConfig{CACertPEM: serverCAPEM(srv)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := without.GetEngineers(context.Background()); err == nil {
+		t.Fatal("expected the server to reject a connection without client certificate")
+	}
+
+	with, err := NewClient(&srv.URL, WithTLS(TLSConfig{
+		CACertPEM:     serverCAPEM(srv),
+		ClientCertPEM: certPEM,
+		ClientKeyPEM:  keyPEM,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := with.GetEngineers(context.Background()); err != nil {
+		t.Fatalf("expected mTLS request to succeed, got %v", err)
+	}
+}
+
+func TestWithTLSRejectsInvalidSettings(t *testing.T) {
+	certPEM, _ := selfSignedPair(t)
+	for name, cfg := range map[string]TLSConfig{
+		"bad CA":         {CACertPEM: []byte("not a certificate")},
+		"cert only":      {ClientCertPEM: certPEM},
+		"mismatched key": {ClientCertPEM: certPEM, ClientKeyPEM: []byte("nope")},
+	} {
+		if _, err := NewClient(nil, WithTLS(cfg)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		okHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	endpoint := "http://dob.invalid"
+	c, err := NewClient(&endpoint, WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetEngineers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if proxied != "http://dob.invalid/engineers" {
+		t.Fatalf("proxy saw %q", proxied)
+	}
+
+	if _, err := NewClient(nil, WithProxy("::nope")); err == nil {
+		t.Fatal("expected an invalid proxy URL to be rejected")
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// socketScheme starts endpoints served on a Unix domain socket, such as
+// unix:///run/dob.sock. An HTTP path prefix follows the socket's path after
+// a colon, as in unix:///run/dob.sock:/dob.
+const socketScheme = "unix://"
+
+// socketEndpoint splits a Unix socket endpoint into the path of the socket
+// and the HTTP path prefix. ok is false for other endpoints.
+func socketEndpoint(endpoint string) (socket, prefix string, ok bool) {
+	rest, ok := strings.CutPrefix(endpoint, socketScheme)
+	if !ok {
+		return "", "", false
+	}
+	socket, prefix, _ = strings.Cut(rest, ":")
+	return socket, strings.TrimSuffix(prefix, "/"), true
+}
+
+// resolveEndpoint returns the HTTP base URL that requests to endpoint are
+// built on. A Unix socket endpoint gets a placeholder host, which the
+// client's transport connects to the socket.
+func (c *Client) resolveEndpoint(endpoint string) string {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	socket, prefix, ok := socketEndpoint(endpoint)
+	if !ok {
+		return endpoint
+	}
+	if c.sockets == nil {
+		c.sockets = map[string]string{}
+	}
+	host := socketHost(socket)
+	c.sockets[host] = socket
+	return "http://" + host + prefix
+}
+
+// socketHost returns the placeholder host of the Unix socket at path. It
+// is derived from the path so that responses cached in a cache directory
+// stay apart across runs, and lies under .invalid so that it never names
+// a real host.
+func socketHost(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(sum[:16]) + ".sock.invalid"
+}
+
+// dialSockets makes the client's transport connect to the Unix sockets of
+// the placeholder hosts resolveEndpoint handed out, bypassing any proxy,
+// and to other hosts as before.
+func (c *Client) dialSockets() {
+	if len(c.sockets) == 0 {
+		return
+	}
+	dial, proxy := c.transport.DialContext, c.transport.Proxy
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	c.transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(addr); err == nil && c.sockets[host] != "" {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", c.sockets[host])
+		}
+		return dial(ctx, network, addr)
+	}
+	c.transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if c.sockets[req.URL.Hostname()] != "" || proxy == nil {
+			return nil, nil
+		}
+		return proxy(req)
+	}
+}
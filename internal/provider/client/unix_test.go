@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSocketServer serves h on a Unix socket and returns the socket's path.
+func newSocketServer(t *testing.T, h http.Handler) string {
+	t.Helper()
+	// Socket paths are limited to about 100 bytes, which t.TempDir can
+	// exceed.
+	dir, err := os.MkdirTemp("", "dob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "dob.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("cannot listen on a Unix socket: %v", err)
+	}
+	srv := httptest.NewUnstartedServer(h)
+	srv.Listener = l
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return socket
+}
+
+func TestSocketEndpoint(t *testing.T) {
+	socket := newSocketServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dob/engineers" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+
+	// Requests reach the socket with the path prefix, and not the proxy
+	// that other endpoints go through.
+	endpoint := "unix://" + socket + ":/dob/"
+	c, err := NewClient(&endpoint, WithRetry(1, 0), WithProxy("http://127.0.0.1:1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetEngineers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CheckHealth(context.Background(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSocketEndpointFailover(t *testing.T) {
+	socket := newSocketServer(t, http.HandlerFunc(okHandler))
+	missing := "unix://" + filepath.Join(filepath.Dir(socket), "missing.sock")
+
+	c, err := NewClient(&missing, WithRetry(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.CheckHealth(context.Background(), time.Second)
+	assertHealthProblem(t, err, HealthUnreachable)
+	if !strings.Contains(err.Error(), "missing.sock") {
+		t.Errorf("error %q does not name the socket", err)
+	}
+
+	c, err = NewClient(&missing, WithRetry(1, 0), WithFailoverEndpoints("unix://"+socket))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetEngineers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSocketHost(t *testing.T) {
+	// The same socket gets the same host in every run, so responses cached
+	// on disk stay with it, and different sockets get different hosts.
+	a, b := socketHost("/run/a.sock"), socketHost("/run/b.sock")
+	if a != socketHost("/run/a.sock") {
+		t.Errorf("socketHost is not stable: %q", a)
+	}
+	if a == b {
+		t.Errorf("sockets share the host %q", a)
+	}
+	if !strings.HasSuffix(a, ".sock.invalid") {
+		t.Errorf("host %q could name a real host", a)
+	}
+
+	var c Client
+	if got, want := c.resolveEndpoint("unix:///run/a.sock:/dob"), "http://"+a+"/dob"; got != want {
+		t.Errorf("resolveEndpoint() = %q, want %q", got, want)
+	}
+	if got := c.resolveEndpoint("http://socket-0"); got != "http://socket-0" || len(c.sockets) != 1 {
+		t.Errorf("resolveEndpoint() = %q with sockets %v, want other hosts left alone", got, c.sockets)
+	}
+}
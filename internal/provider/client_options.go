@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// clientOptionBuilders translate provider configuration into client
+// options. Each builder reports invalid configuration through diags and
+// returns nil when its settings are absent.
+var clientOptionBuilders = []func(context.Context, DOBProviderModel, *diag.Diagnostics) client.Option{
+	failoverOption,
+	retryOption,
+	requestTimeoutOption,
+	authOption,
+	tlsOption,
+	proxyOption,
+	logMaskOption,
+	concurrencyOption,
+	cacheOption,
+	primeReadsOption,
+}
+
+// knownString returns the value of v and whether it was set to a known value.
+func knownString(v types.String) (string, bool) {
+	if v.IsNull() || v.IsUnknown() {
+		return "", false
+	}
+	return v.ValueString(), true
+}
+
+func failoverOption(ctx context.Context, config DOBProviderModel, diags *diag.Diagnostics) client.Option {
+	if config.Endpoints.IsNull() || config.Endpoints.IsUnknown() {
+		return nil
+	}
+	var endpoints []string
+	diags.Append(config.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	for i, endpoint := range endpoints {
+		if err := client.ValidateEndpoint(endpoint); err != nil {
+			diags.AddAttributeError(
+				path.Root("endpoints").AtListIndex(i),
+				"Invalid DOB API Endpoint",
+				fmt.Sprintf("The dob provider cannot use the configured endpoint: %s.", err),
+			)
+		}
+	}
+	return client.WithFailoverEndpoints(endpoints...)
+}
+
+func retryOption(_ context.Context, config DOBProviderModel, diags *diag.Diagnostics) client.Option {
+	setAttempts := !config.RetryMaxAttempts.IsNull() && !config.RetryMaxAttempts.IsUnknown()
+	rawBackoff, setBackoff := knownString(config.RetryMaxBackoff)
+	if !setAttempts && !setBackoff {
+		return nil
+	}
+
+	maxAttempts := int64(client.DefaultMaxAttempts)
+	if setAttempts {
+		maxAttempts = config.RetryMaxAttempts.ValueInt64()
+		if maxAttempts < 1 {
+			diags.AddAttributeError(
+				path.Root("retry_max_attempts"),
+				"Invalid retry_max_attempts",
+				fmt.Sprintf("retry_max_attempts must be at least 1, got %d.", maxAttempts),
+			)
+		}
+	}
+
+	maxBackoff := client.DefaultMaxBackoff
+	if setBackoff {
+		d, err := time.ParseDuration(rawBackoff)
+		if err != nil || d < 0 {
+			diags.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid retry_max_backoff",
+				fmt.Sprintf("retry_max_backoff must be a non-negative duration such as \"10s\", got %q.", rawBackoff),
+			)
+		}
+		maxBackoff = d
+	}
+
+	return client.WithRetry(int(maxAttempts), maxBackoff)
+}
+
+func requestTimeoutOption(_ context.Context, config DOBProviderModel, diags *diag.Diagnostics) client.Option {
+	raw, ok := knownString(config.RequestTimeout)
+	if !ok {
+		return nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			path.Root("request_timeout"),
+			"Invalid request_timeout",
+			fmt.Sprintf("request_timeout must be a positive duration such as \"30s\", got %q.", raw),
+		)
+		return nil
+	}
+	return client.WithRequestTimeout(d)
+}
+
+func authOption(ctx context.Context, config DOBProviderModel, diags *diag.Diagnostics) client.Option {
+	token, hasToken := knownString(config.Token)
+	username, hasUsername := knownString(config.Username)
+	password, hasPassword := knownString(config.Password)
+	command, hasCommand := knownString(config.CredentialProcess)
+	hasOAuth2 := config.OAuth2 != nil
+
+	methods := 0
+	for _, set := range []bool{hasToken, hasUsername || hasPassword, hasCommand, hasOAuth2} {
+		if set {
+			methods++
+		}
+	}
+	if methods > 1 {
+		diags.AddError(
+			"Conflicting Authentication Settings",
+			"Only one of token, username/password, credential_process and the oauth2 block may be configured for the dob provider.",
+		)
+		return nil
+	}
+
+	switch {
+	case hasToken:
+		return client.WithBearerToken(token)
+
+	case hasUsername || hasPassword:
+		if !hasUsername || !hasPassword {
+			diags.AddAttributeError(
+				path.Root("username"),
+				"Incomplete Basic Authentication Settings",
+				"username and password must be configured together.",
+			)
+			return nil
+		}
+		return client.WithBasicAuth(username, password)
+
+	case hasCommand:
+		return client.WithCredentialProcess(command)
+
+	case hasOAuth2:
+		var cfg client.OAuth2Config
+		for _, f := range []struct {
+			name string
+			v    types.String
+			dst  *string
+		}{
+			{"token_url", config.OAuth2.TokenURL, &cfg.TokenURL},
+			{"client_id", config.OAuth2.ClientID, &cfg.ClientID},
+			{"client_secret", config.OAuth2.ClientSecret, &cfg.ClientSecret},
+		} {
+			v, ok := knownString(f.v)
+			if !ok || v == "" {
+				diags.AddAttributeError(
+					path.Root("oauth2").AtName(f.name),
+					"Missing OAuth2 Setting",
+					fmt.Sprintf("oauth2.%s is required when the oauth2 block is configured.", f.name),
+				)
+				continue
+			}
+			*f.dst = v
+		}
+		if !config.OAuth2.Scopes.IsNull() && !config.OAuth2.Scopes.IsUnknown() {
+			diags.Append(config.OAuth2.Scopes.ElementsAs(ctx, &cfg.Scopes, false)...)
+		}
+		return client.WithOAuth2ClientCredentials(cfg)
+	}
+
+	return nil
+}
+
+func tlsOption(_ context.Context, config DOBProviderModel, diags *diag.Diagnostics) client.Option {
+	var cfg client.TLSConfig
+	set := false
+
+	if file, ok := knownString(config.CACertFile); ok {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to Read CA Bundle", err.Error())
+		}
+		cfg.CACertPEM = append(cfg.CACertPEM, pem...)
+		set = true
+	}
+	if pem, ok := knownString(config.CACertPEM); ok {
+		cfg.CACertPEM = append(cfg.CACertPEM, '\n')
+		cfg.CACertPEM = append(cfg.CACertPEM, pem...)
+		set = true
+	}
+
+	cert, hasCert := knownString(config.ClientCert)
+	key, hasKey := knownString(config.ClientKey)
+	if hasCert != hasKey {
+		diags.AddAttributeError(
+			path.Root("client_cert"),
+			"Incomplete Client Certificate Settings",
+			"client_cert and client_key must be configured together.",
+		)
+		return nil
+	}
+	if hasCert {
+		cfg.ClientCertPEM = pemOrFile(cert, path.Root("client_cert"), diags)
+		cfg.ClientKeyPEM = pemOrFile(key, path.Root("client_key"), diags)
+		set = true
+	}
+
+	if !config.InsecureSkipVerify.IsNull() && !config.InsecureSkipVerify.IsUnknown() && config.InsecureSkipVerify.ValueBool() {
+		cfg.InsecureSkipVerify = true
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Verification Disabled",
+			"The provider does not verify the DOB API's TLS certificate. Do not use insecure_skip_verify outside local development.",
+		)
+		set = true
+	}
+
+	if !set {
+		return nil
+	}
+	return client.WithTLS(cfg)
+}
+
+// pemOrFile returns v if it holds PEM data and otherwise reads the file it
+// names.
+func pemOrFile(v string, p path.Path, diags *diag.Diagnostics) []byte {
+	if strings.HasPrefix(strings.TrimSpace(v), "-----BEGIN") {
+		return []byte(v)
+	}
+	b, err := os.ReadFile(v)
+	if err != nil {
+		diags.AddAttributeError(p, "Unable to Read PEM File", err.Error())
+	}
+	return b
+}
+
+func proxyOption(_ context.Context, config DOBProviderModel, _ *diag.Diagnostics) client.Option {
+	proxy, ok := knownString(config.HTTPProxy)
+	if !ok {
+		return nil
+	}
+	return client.WithProxy(proxy)
+}
+
+func logMaskOption(ctx context.Context, config DOBProviderModel, diags *diag.Diagnostics) client.Option {
+	if config.LogMaskedFields.IsNull() || config.LogMaskedFields.IsUnknown() {
+		return nil
+	}
+	var fields []string
+	diags.Append(config.LogMaskedFields.ElementsAs(ctx, &fields, false)...)
+	return client.WithLogMaskedFields(fields)
+}
+
+func concurrencyOption(_ context.Context, config DOBProviderModel, diags *diag.Diagnostics) client.Option {
+	if config.MaxConcurrentRequests.IsNull() || config.MaxConcurrentRequests.IsUnknown() {
+		return nil
+	}
+	n := config.MaxConcurrentRequests.ValueInt64()
+	if n < 1 {
+		diags.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid max_concurrent_requests",
+			fmt.Sprintf("max_concurrent_requests must be at least 1, got %d.", n),
+		)
+		return nil
+	}
+	return client.WithMaxConcurrentRequests(int(n))
+}
+
+func cacheOption(_ context.Context, config DOBProviderModel, _ *diag.Diagnostics) client.Option {
+	dir, ok := knownString(config.CacheDir)
+	if !ok {
+		return nil
+	}
+	return client.WithCacheDir(dir)
+}
+
+func primeReadsOption(_ context.Context, config DOBProviderModel, _ *diag.Diagnostics) client.Option {
+	if !config.PrimeReads.ValueBool() {
+		return nil
+	}
+	return client.WithPrimedReads()
+}
@@ -0,0 +1,146 @@
+// Package concurrency implements optimistic concurrency for the provider's
+// resources. Resources keep the version (ETag) of the object they last saw
+// in private state and send it with If-Match on updates and deletes, so a
+// change made elsewhere in the meantime makes the write fail instead of
+// being overwritten. The failure is reported with the attributes that
+// changed remotely.
+package concurrency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// privateKey is the private state key the version is stored under.
+const privateKey = "etag"
+
+// PrivateState is the read side of a resource's private state.
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// PrivateStateSetter is the write side of a resource's private state.
+type PrivateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// Version returns the version stored in p, or "" if there is none, as for
+// resources created before versions were tracked.
+func Version(ctx context.Context, p PrivateState) (string, diag.Diagnostics) {
+	b, diags := p.GetKey(ctx, privateKey)
+	if diags.HasError() || len(b) == 0 {
+		return "", diags
+	}
+	var version string
+	if err := json.Unmarshal(b, &version); err != nil {
+		// An unreadable version only costs the conflict check.
+		return "", diags
+	}
+	return version, diags
+}
+
+// SetVersion stores version in p. An empty version removes it, so later
+// writes are sent unconditionally.
+func SetVersion(ctx context.Context, p PrivateStateSetter, version string) diag.Diagnostics {
+	if version == "" {
+		return p.SetKey(ctx, privateKey, nil)
+	}
+	b, err := json.Marshal(version)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to store object version", err.Error())
+		return diags
+	}
+	return p.SetKey(ctx, privateKey, b)
+}
+
+// SetReadVersion stores the version a refresh returned. Reads served from
+// a primed list carry no version (see client.WithPrimedReads), so an empty
+// version keeps the one in p, and later writes still fail if the object
+// changed since it was last read individually.
+func SetReadVersion(ctx context.Context, p PrivateStateSetter, version string) diag.Diagnostics {
+	if version == "" {
+		return nil
+	}
+	return SetVersion(ctx, p, version)
+}
+
+// IsConflict reports whether err rejected a write because the object
+// changed since it was read.
+func IsConflict(err error) bool {
+	return client.IsPreconditionFailed(err) || client.IsConflict(err)
+}
+
+// Change is an attribute whose remote value differs from the one in state.
+type Change struct {
+	Attribute string
+	Old, New  attr.Value
+}
+
+// Changes compares two resource models of the same type field by field
+// and returns the attributes, other than id, whose values differ.
+func Changes(prior, remote any) []Change {
+	pv, rv := reflect.Indirect(reflect.ValueOf(prior)), reflect.Indirect(reflect.ValueOf(remote))
+	if pv.Kind() != reflect.Struct || pv.Type() != rv.Type() {
+		return nil
+	}
+
+	var changes []Change
+	for i := range pv.NumField() {
+		name := pv.Type().Field(i).Tag.Get("tfsdk")
+		if name == "" || name == "-" || name == "id" {
+			continue
+		}
+		old, ok := pv.Field(i).Interface().(attr.Value)
+		if !ok {
+			continue
+		}
+		cur := rv.Field(i).Interface().(attr.Value)
+		if !old.Equal(cur) {
+			changes = append(changes, Change{Attribute: name, Old: old, New: cur})
+		}
+	}
+	return changes
+}
+
+// AddConflictError reports a write that err rejected because object
+// changed remotely. prior is the resource's state model and remote the
+// model of the object as re-read after the failure, or nil if it no longer
+// exists. It returns false, adding nothing, for a 409 that is not
+// explained by a remote change, so callers can report err their usual way.
+func AddConflictError(diags *diag.Diagnostics, summary, object string, prior, remote any, err error) bool {
+	if remote == nil {
+		diags.AddError(summary,
+			fmt.Sprintf("%s was deleted outside Terraform after it was last read. "+
+				"Run terraform apply again to plan against the current state.\n\n%s", object, err))
+		return true
+	}
+
+	changes := Changes(prior, remote)
+	if len(changes) == 0 && !client.IsPreconditionFailed(err) {
+		return false
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s was changed outside Terraform after it was last read, "+
+		"so the change was not applied to avoid overwriting it.\n\n", object)
+	if len(changes) == 0 {
+		b.WriteString("No attribute managed by Terraform differs; the object's version changed nonetheless.\n")
+	} else {
+		b.WriteString("Attributes changed remotely:\n")
+		for _, c := range changes {
+			fmt.Fprintf(&b, "  - %s: %s -> %s\n", c.Attribute, c.Old, c.New)
+		}
+	}
+	fmt.Fprintf(&b, "\nRun terraform apply again to plan against the current state.\n\n%s", err)
+	diags.AddError(summary, b.String())
+	return true
+}
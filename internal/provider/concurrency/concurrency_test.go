@@ -0,0 +1,122 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// memPrivate is an in-memory private state.
+type memPrivate map[string][]byte
+
+func (m memPrivate) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return m[key], nil
+}
+
+func (m memPrivate) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(m, key)
+		return nil
+	}
+	m[key] = value
+	return nil
+}
+
+type model struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Email types.String `tfsdk:"email"`
+}
+
+func TestVersionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	p := memPrivate{}
+
+	if v, diags := Version(ctx, p); v != "" || diags.HasError() {
+		t.Fatalf("empty state version = %q, %v", v, diags)
+	}
+	SetVersion(ctx, p, `"abc"`)
+	if v, _ := Version(ctx, p); v != `"abc"` {
+		t.Errorf("version = %q, want %q", v, `"abc"`)
+	}
+	SetReadVersion(ctx, p, "")
+	if v, _ := Version(ctx, p); v != `"abc"` {
+		t.Errorf("version after a read without one = %q, want %q kept", v, `"abc"`)
+	}
+	SetVersion(ctx, p, "")
+	if _, ok := p[privateKey]; ok {
+		t.Error("empty version was stored")
+	}
+}
+
+func TestChanges(t *testing.T) {
+	prior := model{types.StringValue("A"), types.StringValue("Ann"), types.StringValue("ann@example.com")}
+	remote := model{types.StringValue("A"), types.StringValue("Anne"), types.StringValue("ann@example.com")}
+
+	changes := Changes(prior, remote)
+	if len(changes) != 1 || changes[0].Attribute != "name" {
+		t.Fatalf("changes = %+v, want name only", changes)
+	}
+	if len(Changes(prior, prior)) != 0 {
+		t.Error("identical models reported changes")
+	}
+}
+
+func TestAddConflictError(t *testing.T) {
+	prior := model{types.StringValue("A"), types.StringValue("Ann"), types.StringValue("ann@example.com")}
+	changed := model{types.StringValue("A"), types.StringValue("Anne"), types.StringValue("ann@example.com")}
+	stale := &client.APIError{StatusCode: http.StatusPreconditionFailed}
+	conflict := &client.APIError{StatusCode: http.StatusConflict}
+
+	cases := map[string]struct {
+		remote any
+		err    error
+		want   string
+	}{
+		"changed":          {changed, stale, `name: "Ann" -> "Anne"`},
+		"deleted":          {nil, stale, "was deleted outside Terraform"},
+		"version only":     {prior, stale, "version changed nonetheless"},
+		"conflict changed": {changed, conflict, `name: "Ann" -> "Anne"`},
+		"conflict only":    {prior, conflict, ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			added := AddConflictError(&diags, "Conflicting Update", "Engineer A", prior, tc.remote, tc.err)
+			if added != (tc.want != "") {
+				t.Fatalf("added = %v", added)
+			}
+			if !added {
+				if diags.HasError() {
+					t.Errorf("diags = %v, want none", diags)
+				}
+				return
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, tc.want) {
+				t.Errorf("detail = %q, want %q", detail, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	for status, want := range map[int]bool{
+		http.StatusPreconditionFailed: true,
+		http.StatusConflict:           true,
+		http.StatusNotFound:           false,
+	} {
+		if got := IsConflict(&client.APIError{StatusCode: status}); got != want {
+			t.Errorf("IsConflict(%d) = %v, want %v", status, got, want)
+		}
+	}
+	if IsConflict(errors.New("boom")) {
+		t.Error("IsConflict(plain error) = true")
+	}
+}
@@ -3,7 +3,9 @@ package devops
 import (
 	"context"
 
+	"terraform-provider-devops/internal/provider/apidiag"
 	"terraform-provider-devops/internal/provider/client"
+	"terraform-provider-devops/internal/provider/providermeta"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -75,50 +77,57 @@ func (d *devopsDataSource) Configure(_ context.Context, req datasource.Configure
 
 // Read refreshes the Terraform state with the latest data.
 func (d *devopsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-    var state DevopsDataSourceModel
-
-    items, err := d.client.GetDevOps()
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Unable to read DevOps groups",
-            err.Error(),
-        )
-        return
-    }
-
-    for _, it := range items {
-        row := devopsDSModel{
-            ID: types.StringValue(it.ID),
-        }
-
-        // Map dev IDs from []client.Dev
-        devIDs := make([]string, 0, len(it.Dev))
-        for _, d := range it.Dev { devIDs = append(devIDs, d.ID) }
-        devList, diags := types.ListValueFrom(ctx, types.StringType, devIDs)
-        resp.Diagnostics.Append(diags...)
-        if resp.Diagnostics.HasError() {
-            return
-        }
-        row.Devs = devList
-
-        // Map ops IDs from []client.Ops
-        opsIDs := make([]string, 0, len(it.Ops))
-        for _, o := range it.Ops { opsIDs = append(opsIDs, o.ID) }
-        opsList, diags2 := types.ListValueFrom(ctx, types.StringType, opsIDs)
-        resp.Diagnostics.Append(diags2...)
-        if resp.Diagnostics.HasError() {
-            return
-        }
-        row.Ops = opsList
-
-        state.Devops = append(state.Devops, row)
-    }
-
-    diags := resp.State.Set(ctx, &state)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
+	var state DevopsDataSourceModel
+
+	for it, err := range d.client.ListDevOps(ctx) {
+		if err != nil {
+			apidiag.AddDataSourceError(
+				&resp.Diagnostics,
+				"Unable to read DevOps groups",
+				"",
+				err,
+			)
+			return
+		}
+
+		row := devopsDSModel{
+			ID: types.StringValue(it.ID),
+		}
+
+		// Map dev IDs from []client.Dev
+		devIDs := make([]string, 0, len(it.Dev))
+		for _, d := range it.Dev {
+			devIDs = append(devIDs, d.ID)
+		}
+		devList, diags := types.ListValueFrom(ctx, types.StringType, devIDs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		row.Devs = devList
+
+		// Map ops IDs from []client.Ops
+		opsIDs := make([]string, 0, len(it.Ops))
+		for _, o := range it.Ops {
+			opsIDs = append(opsIDs, o.ID)
+		}
+		opsList, diags2 := types.ListValueFrom(ctx, types.StringType, opsIDs)
+		resp.Diagnostics.Append(diags2...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		row.Ops = opsList
+
+		state.Devops = append(state.Devops, row)
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // DataSourceModel maps the data source schema data.
@@ -3,9 +3,16 @@ package devops
 import (
 	"context"
 	"fmt"
-	"strings"
+
+	"terraform-provider-devops/internal/provider/apidiag"
 	"terraform-provider-devops/internal/provider/client"
+	"terraform-provider-devops/internal/provider/concurrency"
+	"terraform-provider-devops/internal/provider/operation"
+	"terraform-provider-devops/internal/provider/providermeta"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -13,7 +20,7 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource = &devopsResource{}
+	_ resource.Resource              = &devopsResource{}
 	_ resource.ResourceWithConfigure = &devopsResource{}
 )
 
@@ -21,7 +28,7 @@ var (
 func NewDevOpsResource() resource.Resource { return &devopsResource{} }
 
 // devopsResource is the resource implementation.
-type devopsResource struct{
+type devopsResource struct {
 	client *client.Client
 }
 
@@ -31,7 +38,7 @@ func (r *devopsResource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 // Schema defines the schema for the resource.
-func (r *devopsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *devopsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -48,41 +55,61 @@ func (r *devopsResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Required:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": operation.Block(ctx),
+		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 // Create a new resource.
 func (r *devopsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	var plan devopsResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() { return }
+	ctx, cancel := operation.Create(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Convert devs and ops lists (types.List of string IDs) to []string
 	var devIDs []string
 	diags = plan.Devs.ElementsAs(ctx, &devIDs, false)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() { return }
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var opsIDs []string
 	diags = plan.Ops.ElementsAs(ctx, &opsIDs, false)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() { return }
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Build slices of minimal objects with only ID set
 	devObjs := make([]client.Dev, 0, len(devIDs))
-	for _, id := range devIDs { devObjs = append(devObjs, client.Dev{ID: id}) }
+	for _, id := range devIDs {
+		devObjs = append(devObjs, client.Dev{ID: id})
+	}
 	opsObjs := make([]client.Ops, 0, len(opsIDs))
-	for _, id := range opsIDs { opsObjs = append(opsObjs, client.Ops{ID: id}) }
+	for _, id := range opsIDs {
+		opsObjs = append(opsObjs, client.Ops{ID: id})
+	}
 
-	reqDevOps := client.DevOps{ Dev: devObjs, Ops: opsObjs }
+	reqDevOps := client.DevOps{Dev: devObjs, Ops: opsObjs}
 
-	created, err := r.client.CreateDevops(reqDevOps)
+	created, version, err := r.client.DevOps.CreateVersioned(ctx, reqDevOps)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		apidiag.AddError(
+			&resp.Diagnostics,
 			"Error creating DevOps",
-			"Could not create DevOps, unexpected error: " + err.Error(),
+			"Could not create DevOps, unexpected error: ",
+			err,
+			devopsAPIFields,
 		)
 		return
 	}
@@ -92,156 +119,205 @@ func (r *devopsResource) Create(ctx context.Context, req resource.CreateRequest,
 	// keep devs/ops lists as provided
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(concurrency.SetVersion(ctx, resp.Private, version)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 // Read resource information.
 func (r *devopsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	var state devopsResourceModel
 
-    // Load current state to get the ID of this resource instance
-    diags := req.State.Get(ctx, &state)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-
-    // Fetch DevOps by ID
-    found, err := r.client.GetDevOpsByID(state.ID.ValueString())
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error Reading DevOps",
-            "Could not read DevOps ID "+state.ID.ValueString()+": "+err.Error(),
-        )
-        return
-    }
-
-    // If the DevOps is not found, remove from state (resource drift)
-    if found == nil {
-        resp.State.RemoveResource(ctx)
-        return
-    }
-
-    // Map found devops to state
-    state.ID = types.StringValue(found.ID)
-    // Extract IDs from []client.Dev and []client.Ops
-    devIDs := make([]string, 0, len(found.Dev))
-    for _, d := range found.Dev { devIDs = append(devIDs, d.ID) }
-    devList, d1 := types.ListValueFrom(ctx, types.StringType, devIDs)
-    resp.Diagnostics.Append(d1...)
-    if resp.Diagnostics.HasError() { return }
-    state.Devs = devList
-
-    opsIDs := make([]string, 0, len(found.Ops))
-    for _, o := range found.Ops { opsIDs = append(opsIDs, o.ID) }
-    opsList, d2 := types.ListValueFrom(ctx, types.StringType, opsIDs)
-    resp.Diagnostics.Append(d2...)
-    if resp.Diagnostics.HasError() { return }
-    state.Ops = opsList
-
-    // Set state
-    diags = resp.State.Set(ctx, &state)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
+	// Load current state to get the ID of this resource instance
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Read(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch DevOps by ID
+	found, version, err := r.client.DevOps.GetVersioned(ctx, state.ID.ValueString())
+	if err != nil {
+		// If the DevOps is gone, remove it from state (resource drift) so
+		// Terraform plans to re-create it.
+		if client.IsNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"DevOps Not Found",
+				fmt.Sprintf("DevOps group %s no longer exists and has been removed from state; Terraform will plan to re-create it.", state.ID.ValueString()),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Reading DevOps",
+			"Could not read DevOps ID "+state.ID.ValueString()+": ",
+			err,
+			nil,
+		)
+		return
+	}
+
+	// Map found devops to state
+	prior := state
+	state, diags = devopsModelFrom(ctx, found)
+	state.Timeouts = prior.Timeouts
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(concurrency.SetReadVersion(ctx, resp.Private, version)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *devopsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-    var plan devopsResourceModel
-    diags := req.Plan.Get(ctx, &plan)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() { return }
-
-    // Load current state to get the persisted ID (plan.ID may be unknown during update)
-    var state devopsResourceModel
-    diags = req.State.Get(ctx, &state)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() { return }
-
-    // Build request from plan
-    var devIDs []string
-    diags = plan.Devs.ElementsAs(ctx, &devIDs, false)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() { return }
-
-    var opsIDs []string
-    diags = plan.Ops.ElementsAs(ctx, &opsIDs, false)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() { return }
-
-    devObjs := make([]client.Dev, 0, len(devIDs))
-    for _, id := range devIDs { devObjs = append(devObjs, client.Dev{ID: id}) }
-    opsObjs := make([]client.Ops, 0, len(opsIDs))
-    for _, id := range opsIDs { opsObjs = append(opsObjs, client.Ops{ID: id}) }
-    reqDevOps := client.DevOps{ Dev: devObjs, Ops: opsObjs }
-
-    // Update existing devops by ID from state
-    _, err := r.client.UpdateDevOps(state.ID.ValueString(), reqDevOps)
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error Updating DevOps",
-            "Could not update DevOps ID "+state.ID.ValueString()+": "+err.Error(),
-        )
-        return
-    }
-
-    // Fetch updated DevOps by ID
-    updated, err := r.client.GetDevOpsByID(state.ID.ValueString())
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error Reading DevOps",
-            "Could not read DevOps ID "+state.ID.ValueString()+": "+err.Error(),
-        )
-        return
-    }
-
-    // Update resource state
-    plan.ID = types.StringValue(updated.ID)
-    devIDs = make([]string, 0, len(updated.Dev))
-    for _, d := range updated.Dev { devIDs = append(devIDs, d.ID) }
-    devList, d1 := types.ListValueFrom(ctx, types.StringType, devIDs)
-    resp.Diagnostics.Append(d1...)
-    if resp.Diagnostics.HasError() { return }
-    plan.Devs = devList
-
-    opsIDs = make([]string, 0, len(updated.Ops))
-    for _, o := range updated.Ops { opsIDs = append(opsIDs, o.ID) }
-    opsList, d2 := types.ListValueFrom(ctx, types.StringType, opsIDs)
-    resp.Diagnostics.Append(d2...)
-    if resp.Diagnostics.HasError() { return }
-    plan.Ops = opsList
-
-    diags = resp.State.Set(ctx, plan)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() { return }
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
+	var plan devopsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Update(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Load current state to get the persisted ID (plan.ID may be unknown during update)
+	var state devopsResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	version, diags := concurrency.Version(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build request from plan
+	var devIDs []string
+	diags = plan.Devs.ElementsAs(ctx, &devIDs, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var opsIDs []string
+	diags = plan.Ops.ElementsAs(ctx, &opsIDs, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devObjs := make([]client.Dev, 0, len(devIDs))
+	for _, id := range devIDs {
+		devObjs = append(devObjs, client.Dev{ID: id})
+	}
+	opsObjs := make([]client.Ops, 0, len(opsIDs))
+	for _, id := range opsIDs {
+		opsObjs = append(opsObjs, client.Ops{ID: id})
+	}
+	reqDevOps := client.DevOps{Dev: devObjs, Ops: opsObjs}
+
+	// Update existing devops by ID from state, unless it changed since it
+	// was last read
+	_, err := r.client.DevOps.UpdateIfMatch(ctx, state.ID.ValueString(), reqDevOps, version)
+	if err != nil {
+		if concurrency.IsConflict(err) && r.addConflictError(ctx, &resp.Diagnostics, "Conflicting Update to DevOps", state, err) {
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Updating DevOps",
+			"Could not update DevOps ID "+state.ID.ValueString()+": ",
+			err,
+			devopsAPIFields,
+		)
+		return
+	}
+
+	// Fetch updated DevOps by ID
+	updated, version, err := r.client.DevOps.GetVersioned(ctx, state.ID.ValueString())
+	if err != nil {
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Reading DevOps",
+			"Could not read DevOps ID "+state.ID.ValueString()+": ",
+			err,
+			nil,
+		)
+		return
+	}
+
+	// Update resource state
+	planned := plan
+	plan, diags = devopsModelFrom(ctx, updated)
+	plan.Timeouts = planned.Timeouts
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(concurrency.SetVersion(ctx, resp.Private, version)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *devopsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-    var state devopsResourceModel
-    diags := req.State.Get(ctx, &state)
-
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-
-    err := r.client.DeleteDevOps(state.ID.ValueString())
-    if err != nil {
-        // If the backend returns 404, treat as already deleted
-        if strings.Contains(err.Error(), "status: 404") {
-            resp.State.RemoveResource(ctx)
-            return
-        }
-        resp.Diagnostics.AddError(
-            "Error Deleting DevOps",
-            fmt.Sprintf("Could not delete DevOps ID %s: %v", state.ID.ValueString(), err),
-        )
-        return
-    }
-    // Successful delete; nothing else to do. Terraform will drop state for this resource.
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
+	var state devopsResourceModel
+	diags := req.State.Get(ctx, &state)
+
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Delete(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version, diags := concurrency.Version(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing devops, unless it changed since it was last read
+	err := r.client.DevOps.DeleteIfMatch(ctx, state.ID.ValueString(), version)
+	if err != nil {
+		// If the backend returns 404, treat as already deleted
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if concurrency.IsConflict(err) && r.addConflictError(ctx, &resp.Diagnostics, "Conflicting Delete of DevOps", state, err) {
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Deleting DevOps",
+			"Could not delete DevOps ID "+state.ID.ValueString()+": ",
+			err,
+			nil,
+		)
+		return
+	}
+	// Successful delete; nothing else to do. Terraform will drop state for this resource.
 }
 
 func (r *devopsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -265,9 +341,61 @@ func (r *devopsResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// addConflictError re-reads the DevOps group after a write failed with err
+// and reports what changed remotely; see concurrency.AddConflictError.
+func (r *devopsResource) addConflictError(ctx context.Context, diags *diag.Diagnostics, summary string, prior devopsResourceModel, err error) bool {
+	object := "DevOps group " + prior.ID.ValueString()
+	found, getErr := r.client.GetDevOpsByID(ctx, prior.ID.ValueString())
+	switch {
+	case client.IsNotFound(getErr):
+		return concurrency.AddConflictError(diags, summary, object, prior, nil, err)
+	case getErr != nil:
+		return false
+	}
+	remote, d := devopsModelFrom(ctx, found)
+	if d.HasError() {
+		return false
+	}
+	remote.Timeouts = prior.Timeouts
+	return concurrency.AddConflictError(diags, summary, object, prior, remote, err)
+}
+
+// devopsModelFrom maps an API DevOps group to the resource model, keeping
+// only the IDs of its dev and ops groups.
+func devopsModelFrom(ctx context.Context, devops *client.DevOps) (devopsResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	devIDs := make([]string, 0, len(devops.Dev))
+	for _, d := range devops.Dev {
+		devIDs = append(devIDs, d.ID)
+	}
+	devList, d := types.ListValueFrom(ctx, types.StringType, devIDs)
+	diags.Append(d...)
+
+	opsIDs := make([]string, 0, len(devops.Ops))
+	for _, o := range devops.Ops {
+		opsIDs = append(opsIDs, o.ID)
+	}
+	opsList, d := types.ListValueFrom(ctx, types.StringType, opsIDs)
+	diags.Append(d...)
+
+	return devopsResourceModel{
+		ID:   types.StringValue(devops.ID),
+		Devs: devList,
+		Ops:  opsList,
+	}, diags
+}
+
 // devopsResourceModel maps the resource schema data.
 type devopsResourceModel struct {
-	ID          types.String    `tfsdk:"id"`
-	Devs        types.List      `tfsdk:"devs"`
-	Ops         types.List      `tfsdk:"ops"`
+	ID       types.String   `tfsdk:"id"`
+	Devs     types.List     `tfsdk:"devs"`
+	Ops      types.List     `tfsdk:"ops"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// devopsAPIFields maps DOB API validation error fields to dob_devops attributes.
+var devopsAPIFields = apidiag.Fields{
+	"dev":  path.Root("devs"),
+	"devs": path.Root("devs"),
+	"ops":  path.Root("ops"),
 }
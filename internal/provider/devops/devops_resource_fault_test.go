@@ -0,0 +1,38 @@
+package devops_test
+
+import (
+	"net/http"
+	"testing"
+
+	"terraform-provider-devops/internal/faultinject"
+	"terraform-provider-devops/internal/provider/devops"
+	"terraform-provider-devops/internal/resourcetest"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDevOpsResourceFaults(t *testing.T) {
+	fixture := resourcetest.Fixture{
+		Create: resourcetest.Attrs{"id": tftypes.UnknownValue, "devs": []string{"BBBBB"}, "ops": []string{"CCCCC"}},
+		State:  resourcetest.Attrs{"id": "DDDDD", "devs": []string{"BBBBB"}, "ops": []string{"CCCCC"}},
+		Update: resourcetest.Attrs{"id": tftypes.UnknownValue, "devs": []string{"BBBBB"}, "ops": []string{}},
+	}
+	resourcetest.RunFaults(t, devops.NewDevOpsResource, fixture, map[string]resourcetest.FaultCase{
+		"create server error":         {Op: resourcetest.Create, Method: http.MethodPost, Path: "/devops", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error creating DevOps"},
+		"create server error applied": {Op: resourcetest.Create, Method: http.MethodPost, Path: "/devops", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError, Forward: true}, Summary: "Error creating DevOps"},
+		"create dropped":              {Op: resourcetest.Create, Method: http.MethodPost, Path: "/devops", Fault: faultinject.Fault{Drop: true}, Summary: "Error creating DevOps"},
+		"create truncated body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/devops", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error creating DevOps"},
+		"create corrupted body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/devops", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error creating DevOps"},
+
+		"update fails":   {Op: resourcetest.Update, Method: http.MethodPut, Path: "/devops/DDDDD", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Updating DevOps"},
+		"update dropped": {Op: resourcetest.Update, Method: http.MethodPut, Path: "/devops/DDDDD", Fault: faultinject.Fault{Drop: true}, Summary: "Error Updating DevOps"},
+		// The update goes through but reading it back fails: the prior state
+		// must be kept rather than the unconfirmed plan.
+		"update read back fails":     {Op: resourcetest.Update, Method: http.MethodGet, Path: "/devops/DDDDD", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error Reading DevOps"},
+		"update read back truncated": {Op: resourcetest.Update, Method: http.MethodGet, Path: "/devops/DDDDD", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading DevOps"},
+
+		"read server error":     {Op: resourcetest.Read, Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Reading DevOps", Detail: "500"},
+		"read connection reset": {Op: resourcetest.Read, Fault: faultinject.Fault{ResetBody: true}, Summary: "Error Reading DevOps", Detail: "connection reset"},
+		"read truncated body":   {Op: resourcetest.Read, Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading DevOps", Detail: "/devops/DDDDD"},
+	})
+}
@@ -1,18 +1,25 @@
 package devops_test
 
 import (
+    "context"
+    "fmt"
+    "terraform-provider-devops/internal/acctest"
+    "terraform-provider-devops/internal/provider/devops"
+    "terraform-provider-devops/internal/resourcetest"
     "testing"
 
     "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+    "github.com/hashicorp/terraform-plugin-testing/plancheck"
+    "github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccDevOpsResource(t *testing.T) {
     resource.Test(t, resource.TestCase{
-        ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+        ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories(t),
         Steps: []resource.TestStep{
             // Create and Read testing
             {
-                Config: providerConfig + `
+                Config: acctest.ProviderConfig() + `
 resource "dob_engineer" "e1" {
     name  = "Test Engineer 123"
     email = "testuser1@liatrio.com"
@@ -36,12 +43,104 @@ resource "dob_ops" "test" {
 resource "dob_devops" "test" {
     devs = [dob_dev.test.id]
     ops = [dob_ops.test.id]
+
+    timeouts {
+        create = "45m"
+    }
 }
 `,
                 Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("dob_devops.test", "id"),
+                    resource.TestCheckResourceAttr("dob_devops.test", "timeouts.create", "45m"),
+                ),
+            },
+        },
+    })
+}
+
+func TestAccDevOpsResource_disappears(t *testing.T) {
+    resource.Test(t, resource.TestCase{
+        ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories(t),
+        Steps: []resource.TestStep{
+            {
+                Config: acctest.ProviderConfig() + `
+resource "dob_engineer" "e1" {
+    name  = "Disappearing Engineer 1"
+    email = "disappearing1@liatrio.com"
+}
+
+resource "dob_engineer" "e2" {
+    name  = "Disappearing Engineer 2"
+    email = "disappearing2@liatrio.com"
+}
+
+resource "dob_dev" "test" {
+    name = "Disappearing Dev"
+    engineers = [dob_engineer.e1.id]
+}
+
+resource "dob_ops" "test" {
+    name = "Disappearing Ops"
+    engineers = [dob_engineer.e2.id]
+}
+
+resource "dob_devops" "test" {
+    devs = [dob_dev.test.id]
+    ops = [dob_ops.test.id]
+}
+`,
+                Check: resource.ComposeAggregateTestCheckFunc(
+                    resource.TestCheckResourceAttrSet("dob_devops.test", "id"),
+                    testAccDeleteDevOpsOutOfBand(t, "dob_devops.test"),
                 ),
+                // The refresh after apply finds the DevOps group missing, warns
+                // and plans to re-create it.
+                ConfigPlanChecks: resource.ConfigPlanChecks{
+                    PostApplyPostRefresh: []plancheck.PlanCheck{
+                        plancheck.ExpectResourceAction("dob_devops.test", plancheck.ResourceActionCreate),
+                    },
+                },
+                ExpectNonEmptyPlan: true,
             },
         },
     })
 }
+
+// testAccDeleteDevOpsOutOfBand deletes the DevOps group behind resourceName
+// directly through the API, simulating a deletion outside Terraform.
+func testAccDeleteDevOpsOutOfBand(t *testing.T, resourceName string) resource.TestCheckFunc {
+    return func(s *terraform.State) error {
+        rs, ok := s.RootModule().Resources[resourceName]
+        if !ok {
+            return fmt.Errorf("resource %s not found in state", resourceName)
+        }
+
+        c, err := acctest.NewClient(t)
+        if err != nil {
+            return err
+        }
+        return c.DeleteDevOps(context.Background(), rs.Primary.ID)
+    }
+}
+
+// A Read that finds the group gone warns and removes it from state, so
+// Terraform plans to re-create it.
+func TestDevOpsResourceReadDisappeared(t *testing.T) {
+    r := resourcetest.New(t, devops.NewDevOpsResource, nil)
+    ctx := context.Background()
+    if err := r.Client.DeleteDevOps(ctx, "DDDDD"); err != nil {
+        t.Fatal(err)
+    }
+
+    resp := r.Read(t, resourcetest.Attrs{"id": "DDDDD", "devs": []string{"BBBBB"}, "ops": []string{"CCCCC"}})
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("unexpected error: %v", resp.Diagnostics)
+    }
+    warnings := resp.Diagnostics.Warnings()
+    if len(warnings) != 1 || warnings[0].Summary() != "DevOps Not Found" {
+        t.Errorf("warnings = %v, want one %q", warnings, "DevOps Not Found")
+    }
+    if !resp.State.Raw.IsNull() {
+        t.Errorf("state = %v, want the DevOps group removed so it is re-created", resp.State.Raw)
+    }
+}
@@ -3,7 +3,10 @@ package devs
 import (
 	"context"
 
+	"terraform-provider-devops/internal/provider/apidiag"
+	"terraform-provider-devops/internal/provider/capability"
 	"terraform-provider-devops/internal/provider/client"
+	"terraform-provider-devops/internal/provider/providermeta"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -39,8 +42,9 @@ func (d *devDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id":   schema.StringAttribute{Computed: true},
-						"name": schema.StringAttribute{Computed: true},
+						"id":          schema.StringAttribute{Computed: true},
+						"name":        schema.StringAttribute{Computed: true},
+						"description": schema.StringAttribute{Computed: true},
 						"engineers": schema.ListAttribute{
 							Computed:    true,
 							ElementType: types.StringType,
@@ -72,21 +76,25 @@ func (d *devDataSource) Configure(_ context.Context, req datasource.ConfigureReq
 
 // Read refreshes the Terraform state with the latest data.
 func (d *devDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	var state DevDataSourceModel
 
-	devs, err := d.client.GetDev()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read Dev groups",
-			err.Error(),
-		)
-		return
-	}
+	for dv, err := range d.client.ListDev(ctx) {
+		if err != nil {
+			apidiag.AddDataSourceError(
+				&resp.Diagnostics,
+				"Unable to read Dev groups",
+				"",
+				err,
+			)
+			return
+		}
 
-	for _, dv := range devs {
 		dvm := devDSModel{
-			ID:   types.StringValue(dv.ID),
-			Name: types.StringValue(dv.Name),
+			ID:          types.StringValue(dv.ID),
+			Name:        types.StringValue(dv.Name),
+			Description: capability.OptionalString(dv.Description),
 		}
 
 		// Convert engineers (objects) to a list of engineer IDs
@@ -118,9 +126,10 @@ type DevDataSourceModel struct {
 
 // devModel maps Dev schema data.
 type devDSModel struct {
-	ID        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Engineers types.List   `tfsdk:"engineers"`
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Engineers   types.List   `tfsdk:"engineers"`
 }
 
 // DevInfoModel maps Dev info data
@@ -3,9 +3,17 @@ package devs
 import (
 	"context"
 	"fmt"
-	"strings"
+
+	"terraform-provider-devops/internal/provider/apidiag"
+	"terraform-provider-devops/internal/provider/capability"
 	"terraform-provider-devops/internal/provider/client"
+	"terraform-provider-devops/internal/provider/concurrency"
+	"terraform-provider-devops/internal/provider/operation"
+	"terraform-provider-devops/internal/provider/providermeta"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -13,15 +21,16 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource = &devResource{}
-	_ resource.ResourceWithConfigure = &devResource{}
+	_ resource.Resource               = &devResource{}
+	_ resource.ResourceWithConfigure  = &devResource{}
+	_ resource.ResourceWithModifyPlan = &devResource{}
 )
 
 // NewDevResource is a helper function to simplify the provider implementation.
 func NewDevResource() resource.Resource { return &devResource{} }
 
 // devResource is the resource implementation.
-type devResource struct{
+type devResource struct {
 	client *client.Client
 }
 
@@ -31,7 +40,7 @@ func (r *devResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *devResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *devResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -41,44 +50,65 @@ func (r *devResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			"name": schema.StringAttribute{
 				Required: true,
 			},
+			// Input: description, kept only by API versions with group descriptions
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
 			// Input: list of engineer IDs
 			"engineers": schema.ListAttribute{
 				ElementType: types.StringType,
 				Required:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": operation.Block(ctx),
+		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 // Create a new resource.
 func (r *devResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	var plan devResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() { return }
+	ctx, cancel := operation.Create(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Convert engineers list (types.List of string IDs) to []client.Engineer with only IDs populated
-    var engineerIDs []string
-    diags = plan.Engineers.ElementsAs(ctx, &engineerIDs, false)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() { return }
-
-    engs := make([]client.Engineer, 0, len(engineerIDs))
-    for _, id := range engineerIDs {
-        engs = append(engs, client.Engineer{ID: id})
-    }
-
-    reqDev := client.Dev{
-        Name:      plan.Name.ValueString(),
-        Engineers: engs,
-    }
-
-	created, err := r.client.CreateDev(reqDev)
+	var engineerIDs []string
+	diags = plan.Engineers.ElementsAs(ctx, &engineerIDs, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	engs := make([]client.Engineer, 0, len(engineerIDs))
+	for _, id := range engineerIDs {
+		engs = append(engs, client.Engineer{ID: id})
+	}
+
+	reqDev := client.Dev{
+		Name:      plan.Name.ValueString(),
+		Engineers: engs,
+	}
+	if capability.Supported(ctx, r.client, client.CapabilityGroupDescriptions) {
+		reqDev.Description = plan.Description.ValueString()
+	}
+
+	created, version, err := r.client.Devs.CreateVersioned(ctx, reqDev)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		apidiag.AddError(
+			&resp.Diagnostics,
 			"Error creating Dev",
-			"Could not create Dev group, unexpected error: "+err.Error(),
+			"Could not create Dev group, unexpected error: ",
+			err,
+			devAPIFields,
 		)
 		return
 	}
@@ -89,162 +119,200 @@ func (r *devResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// keep engineers list as provided
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(concurrency.SetVersion(ctx, resp.Private, version)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 // Read resource information.
 func (r *devResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-    var state devResourceModel
-
-    // Load current state to get the ID of this resource instance
-    diags := req.State.Get(ctx, &state)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-
-    // Fetch Dev by ID
-    found, err := r.client.GetDevByID(state.ID.ValueString())
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error Reading Dev",
-            "Could not read Dev ID "+state.ID.ValueString()+": "+err.Error(),
-        )
-        return
-    }
-
-    // If the Dev is not found, remove from state (resource drift)
-    if found == nil {
-        resp.State.RemoveResource(ctx)
-        return
-    }
-
-    // Map found dev to state
-    state.ID = types.StringValue(found.ID)
-    state.Name = types.StringValue(found.Name)
-
-    // Convert engineers to a list of engineer IDs as the schema expects list(string)
-    engineerIDs := make([]string, 0, len(found.Engineers))
-    for _, eng := range found.Engineers {
-        engineerIDs = append(engineerIDs, eng.ID)
-    }
-
-    engList, diags2 := types.ListValueFrom(ctx, types.StringType, engineerIDs)
-    resp.Diagnostics.Append(diags2...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-    state.Engineers = engList
-
-    // Set state
-    diags = resp.State.Set(ctx, &state)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-}
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
 
+	var state devResourceModel
+
+	// Load current state to get the ID of this resource instance
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Read(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch Dev by ID
+	found, version, err := r.client.Devs.GetVersioned(ctx, state.ID.ValueString())
+	if err != nil {
+		// If the Dev is gone, remove it from state (resource drift) so
+		// Terraform plans to re-create it.
+		if client.IsNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"Dev Not Found",
+				fmt.Sprintf("Dev group %s no longer exists and has been removed from state; Terraform will plan to re-create it.", state.ID.ValueString()),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Reading Dev",
+			"Could not read Dev ID "+state.ID.ValueString()+": ",
+			err,
+			nil,
+		)
+		return
+	}
+
+	// Map found dev to state
+	prior := state
+	state, diags = devModelFrom(ctx, found)
+	state.Description = capability.String(r.client, client.CapabilityGroupDescriptions, state.Description, prior.Description)
+	state.Timeouts = prior.Timeouts
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(concurrency.SetReadVersion(ctx, resp.Private, version)...)
+}
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *devResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-    var plan devResourceModel
-    diags := req.Plan.Get(ctx, &plan)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-
-    // Load current state to get the persisted ID (plan.ID may be unknown during update)
-    var state devResourceModel
-    diags = req.State.Get(ctx, &state)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-
-    // Generate API request body from plan
-    var reqDev = client.Dev{
-        Name: plan.Name.ValueString(),
-    }
-    // Convert engineers list (types.List of string IDs) to []client.Engineer
-    var engineerIDs []string
-    diags = plan.Engineers.ElementsAs(ctx, &engineerIDs, false)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-    engs := make([]client.Engineer, 0, len(engineerIDs))
-    for _, id := range engineerIDs {
-        engs = append(engs, client.Engineer{ID: id})
-    }
-    reqDev.Engineers = engs
-
-    // Update existing dev by ID from state
-    _, err := r.client.UpdateDev(state.ID.ValueString(), reqDev)
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error Updating Dev",
-            "Could not update Dev ID "+state.ID.ValueString()+": "+err.Error(),
-        )
-        return
-    }
-
-    // Fetch updated Dev by ID
-    dev, err := r.client.GetDevByID(state.ID.ValueString())
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error Reading Dev",
-            "Could not read Dev ID "+state.ID.ValueString()+": "+err.Error(),
-        )
-        return
-    }
-
-    // Update resource state with updated items and timestamp
-    plan.ID = types.StringValue(dev.ID)
-    plan.Name = types.StringValue(dev.Name)
-    // map engineers back into list(string) of IDs
-    updatedEngineerIDs := make([]string, 0, len(dev.Engineers))
-    for _, eng := range dev.Engineers {
-        updatedEngineerIDs = append(updatedEngineerIDs, eng.ID)
-    }
-    engList, diags2 := types.ListValueFrom(ctx, types.StringType, updatedEngineerIDs)
-    resp.Diagnostics.Append(diags2...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-    plan.Engineers = engList
-
-    diags = resp.State.Set(ctx, plan)
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
+	var plan devResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Update(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Load current state to get the persisted ID (plan.ID may be unknown during update)
+	var state devResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	version, diags := concurrency.Version(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	var reqDev = client.Dev{
+		Name: plan.Name.ValueString(),
+	}
+	if capability.Supported(ctx, r.client, client.CapabilityGroupDescriptions) {
+		reqDev.Description = plan.Description.ValueString()
+	}
+	// Convert engineers list (types.List of string IDs) to []client.Engineer
+	var engineerIDs []string
+	diags = plan.Engineers.ElementsAs(ctx, &engineerIDs, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	engs := make([]client.Engineer, 0, len(engineerIDs))
+	for _, id := range engineerIDs {
+		engs = append(engs, client.Engineer{ID: id})
+	}
+	reqDev.Engineers = engs
+
+	// Update existing dev by ID from state, unless it changed since it was last read
+	_, err := r.client.Devs.UpdateIfMatch(ctx, state.ID.ValueString(), reqDev, version)
+	if err != nil {
+		if concurrency.IsConflict(err) && r.addConflictError(ctx, &resp.Diagnostics, "Conflicting Update to Dev", state, err) {
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Updating Dev",
+			"Could not update Dev ID "+state.ID.ValueString()+": ",
+			err,
+			devAPIFields,
+		)
+		return
+	}
+
+	// Fetch updated Dev by ID
+	dev, version, err := r.client.Devs.GetVersioned(ctx, state.ID.ValueString())
+	if err != nil {
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Reading Dev",
+			"Could not read Dev ID "+state.ID.ValueString()+": ",
+			err,
+			nil,
+		)
+		return
+	}
+
+	// Update resource state with updated items
+	planned := plan
+	plan, diags = devModelFrom(ctx, dev)
+	plan.Description = capability.String(r.client, client.CapabilityGroupDescriptions, plan.Description, planned.Description)
+	plan.Timeouts = planned.Timeouts
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(concurrency.SetVersion(ctx, resp.Private, version)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *devResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-    var state devResourceModel
-    diags := req.State.Get(ctx, &state)
-
-    resp.Diagnostics.Append(diags...)
-    if resp.Diagnostics.HasError() {
-        return
-    }
-
-    err := r.client.DeleteDev(state.ID.ValueString())
-    if err != nil {
-        // If the backend returns 404, treat as already deleted
-        if strings.Contains(err.Error(), "status: 404") {
-            resp.State.RemoveResource(ctx)
-            return
-        }
-        resp.Diagnostics.AddError(
-            "Error Deleting Dev",
-            fmt.Sprintf("Could not delete Dev ID %s: %v", state.ID.ValueString(), err),
-        )
-        return
-    }
-    // Successful delete; nothing else to do. Terraform will drop state for this resource.
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
+	var state devResourceModel
+	diags := req.State.Get(ctx, &state)
+
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Delete(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version, diags := concurrency.Version(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Devs.DeleteIfMatch(ctx, state.ID.ValueString(), version)
+	if err != nil {
+		// If the backend returns 404, treat as already deleted
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if concurrency.IsConflict(err) && r.addConflictError(ctx, &resp.Diagnostics, "Conflicting Delete of Dev", state, err) {
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
+			"Error Deleting Dev",
+			"Could not delete Dev ID "+state.ID.ValueString()+": ",
+			err,
+			nil,
+		)
+		return
+	}
+	// Successful delete; nothing else to do. Terraform will drop state for this resource.
 }
 
 func (r *devResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -268,9 +336,63 @@ func (r *devResource) Configure(_ context.Context, req resource.ConfigureRequest
 	r.client = client
 }
 
+// ModifyPlan warns when the configuration sets a description the DOB API
+// does not keep.
+func (r *devResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	capability.WarnUnsupported(ctx, r.client, client.CapabilityGroupDescriptions, req.Config, path.Root("description"), &resp.Diagnostics)
+}
+
+// addConflictError re-reads the Dev group after a write failed with err
+// and reports what changed remotely; see concurrency.AddConflictError.
+func (r *devResource) addConflictError(ctx context.Context, diags *diag.Diagnostics, summary string, prior devResourceModel, err error) bool {
+	object := "Dev group " + prior.ID.ValueString()
+	found, getErr := r.client.GetDevByID(ctx, prior.ID.ValueString())
+	switch {
+	case client.IsNotFound(getErr):
+		return concurrency.AddConflictError(diags, summary, object, prior, nil, err)
+	case getErr != nil:
+		return false
+	}
+	remote, d := devModelFrom(ctx, found)
+	if d.HasError() {
+		return false
+	}
+	remote.Description = capability.String(r.client, client.CapabilityGroupDescriptions, remote.Description, prior.Description)
+	remote.Timeouts = prior.Timeouts
+	return concurrency.AddConflictError(diags, summary, object, prior, remote, err)
+}
+
+// devModelFrom maps an API Dev group to the resource model, with its
+// engineers as a list of IDs.
+func devModelFrom(ctx context.Context, dev *client.Dev) (devResourceModel, diag.Diagnostics) {
+	engineerIDs := make([]string, 0, len(dev.Engineers))
+	for _, eng := range dev.Engineers {
+		engineerIDs = append(engineerIDs, eng.ID)
+	}
+	engList, diags := types.ListValueFrom(ctx, types.StringType, engineerIDs)
+	return devResourceModel{
+		ID:          types.StringValue(dev.ID),
+		Name:        types.StringValue(dev.Name),
+		Description: capability.OptionalString(dev.Description),
+		Engineers:   engList,
+	}, diags
+}
+
 // devResourceModel maps the resource schema data.
 type devResourceModel struct {
-	ID          types.String    `tfsdk:"id"`
-	Name        types.String    `tfsdk:"name"`
-	Engineers   types.List      `tfsdk:"engineers"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Engineers   types.List     `tfsdk:"engineers"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// devAPIFields maps DOB API validation error fields to dob_dev attributes.
+var devAPIFields = apidiag.Fields{
+	"name":        path.Root("name"),
+	"description": path.Root("description"),
+	"engineers":   path.Root("engineers"),
 }
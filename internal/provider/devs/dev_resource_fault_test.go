@@ -0,0 +1,38 @@
+package devs_test
+
+import (
+	"net/http"
+	"testing"
+
+	"terraform-provider-devops/internal/faultinject"
+	"terraform-provider-devops/internal/provider/devs"
+	"terraform-provider-devops/internal/resourcetest"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDevResourceFaults(t *testing.T) {
+	fixture := resourcetest.Fixture{
+		Create: resourcetest.Attrs{"id": tftypes.UnknownValue, "name": "Build", "engineers": []string{"AAAAA"}},
+		State:  resourcetest.Attrs{"id": "BBBBB", "name": "Platform", "engineers": []string{"AAAAA"}},
+		Update: resourcetest.Attrs{"id": tftypes.UnknownValue, "name": "Release", "engineers": []string{"AAAAA"}},
+	}
+	resourcetest.RunFaults(t, devs.NewDevResource, fixture, map[string]resourcetest.FaultCase{
+		"create server error":         {Op: resourcetest.Create, Method: http.MethodPost, Path: "/dev", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error creating Dev"},
+		"create server error applied": {Op: resourcetest.Create, Method: http.MethodPost, Path: "/dev", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError, Forward: true}, Summary: "Error creating Dev"},
+		"create dropped":              {Op: resourcetest.Create, Method: http.MethodPost, Path: "/dev", Fault: faultinject.Fault{Drop: true}, Summary: "Error creating Dev"},
+		"create truncated body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/dev", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error creating Dev"},
+		"create corrupted body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/dev", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error creating Dev"},
+
+		"update fails":   {Op: resourcetest.Update, Method: http.MethodPut, Path: "/dev/BBBBB", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Updating Dev"},
+		"update dropped": {Op: resourcetest.Update, Method: http.MethodPut, Path: "/dev/BBBBB", Fault: faultinject.Fault{Drop: true}, Summary: "Error Updating Dev"},
+		// The update goes through but reading it back fails: the prior state
+		// must be kept rather than the unconfirmed plan.
+		"update read back fails":     {Op: resourcetest.Update, Method: http.MethodGet, Path: "/dev/id/BBBBB", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error Reading Dev"},
+		"update read back truncated": {Op: resourcetest.Update, Method: http.MethodGet, Path: "/dev/id/BBBBB", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading Dev"},
+
+		"read server error":     {Op: resourcetest.Read, Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Reading Dev", Detail: "500"},
+		"read connection reset": {Op: resourcetest.Read, Fault: faultinject.Fault{ResetBody: true}, Summary: "Error Reading Dev", Detail: "connection reset"},
+		"read truncated body":   {Op: resourcetest.Read, Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading Dev", Detail: "/dev/id/BBBBB"},
+	})
+}
@@ -0,0 +1,86 @@
+package devs_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-devops/internal/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// A refresh served from a primed list has no version to offer, so the
+// update that follows must still be conditional on the version of the last
+// individual read and fail when the group changed since.
+func TestAccDevResource_primedReadsConflict(t *testing.T) {
+	config := `
+resource "dob_engineer" "e1" {
+    name  = "Primed Engineer"
+    email = "primed@liatrio.com"
+}
+
+resource "dob_dev" "test" {
+    name      = "Primed Dev"
+    engineers = [dob_engineer.e1.id]
+}
+`
+	primed := fmt.Sprintf(`
+provider "dob" {
+    endpoint    = %q
+    prime_reads = true
+}
+`, acctest.Endpoint())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: primed + config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dob_dev.test", "name", "Primed Dev"),
+					testAccRenameDevOutOfBand(t, "dob_dev.test", "Renamed Dev"),
+				),
+				// The refresh after apply sees the new name and plans to
+				// restore it.
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      primed + config,
+				ExpectError: regexp.MustCompile("Conflicting Update to Dev"),
+			},
+			{
+				// An individual read picks up the current version, after
+				// which the update goes through.
+				Config: acctest.ProviderConfig() + config,
+				Check:  resource.TestCheckResourceAttr("dob_dev.test", "name", "Primed Dev"),
+			},
+		},
+	})
+}
+
+// testAccRenameDevOutOfBand renames the Dev group behind resourceName
+// directly through the API, simulating a change outside Terraform.
+func testAccRenameDevOutOfBand(t *testing.T, resourceName, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		c, err := acctest.NewClient(t)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		dev, err := c.GetDevByID(ctx, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		dev.Name = name
+		_, err = c.UpdateDev(ctx, dev.ID, *dev)
+		return err
+	}
+}
@@ -1,18 +1,25 @@
 package devs_test
 
 import (
+    "context"
+    "fmt"
+    "terraform-provider-devops/internal/acctest"
+    "terraform-provider-devops/internal/provider/devs"
+    "terraform-provider-devops/internal/resourcetest"
     "testing"
 
     "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+    "github.com/hashicorp/terraform-plugin-testing/plancheck"
+    "github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccDevResource(t *testing.T) {
     resource.Test(t, resource.TestCase{
-        ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+        ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories(t),
         Steps: []resource.TestStep{
             // Create and Read testing
             {
-                Config: providerConfig + `
+                Config: acctest.ProviderConfig() + `
 resource "dob_engineer" "e1" {
     name  = "Test Engineer 1"
     email = "testuser1@liatrio.com"
@@ -32,7 +39,7 @@ resource "dob_dev" "test" {
             },
             // Update and Read testing
             {
-                Config: providerConfig + `
+                Config: acctest.ProviderConfig() + `
 resource "dob_engineer" "e1" {
     name  = "Test Engineer 1"
     email = "testuser1@liatrio.com"
@@ -45,11 +52,13 @@ resource "dob_engineer" "e2" {
 
 resource "dob_dev" "test" {
     name = "Test User 123"
+    description = "Owns the release pipeline"
     engineers = [dob_engineer.e1.id, dob_engineer.e2.id]
 }
 `,
                 Check: resource.ComposeAggregateTestCheckFunc(
                     resource.TestCheckResourceAttr("dob_dev.test", "name", "Test User 123"),
+                    resource.TestCheckResourceAttr("dob_dev.test", "description", "Owns the release pipeline"),
                     resource.TestCheckResourceAttr("dob_dev.test", "engineers.#", "2"),
                     resource.TestCheckResourceAttrSet("dob_dev.test", "engineers.0"),
                     resource.TestCheckResourceAttrSet("dob_dev.test", "engineers.1"),
@@ -60,3 +69,78 @@ resource "dob_dev" "test" {
         },
     })
 }
+
+func TestAccDevResource_disappears(t *testing.T) {
+    resource.Test(t, resource.TestCase{
+        ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories(t),
+        Steps: []resource.TestStep{
+            {
+                Config: acctest.ProviderConfig() + `
+resource "dob_engineer" "e1" {
+    name  = "Disappearing Engineer"
+    email = "disappearing@liatrio.com"
+}
+
+resource "dob_dev" "test" {
+    name = "Disappearing Dev"
+    engineers = [dob_engineer.e1.id]
+}
+`,
+                Check: resource.ComposeAggregateTestCheckFunc(
+                    resource.TestCheckResourceAttrSet("dob_dev.test", "id"),
+                    testAccDeleteDevOutOfBand(t, "dob_dev.test"),
+                ),
+                // The refresh after apply finds the Dev group missing, warns
+                // and plans to re-create it.
+                ConfigPlanChecks: resource.ConfigPlanChecks{
+                    PostApplyPostRefresh: []plancheck.PlanCheck{
+                        plancheck.ExpectResourceAction("dob_dev.test", plancheck.ResourceActionCreate),
+                    },
+                },
+                ExpectNonEmptyPlan: true,
+            },
+        },
+    })
+}
+
+// testAccDeleteDevOutOfBand deletes the Dev group behind resourceName
+// directly through the API, simulating a deletion outside Terraform.
+func testAccDeleteDevOutOfBand(t *testing.T, resourceName string) resource.TestCheckFunc {
+    return func(s *terraform.State) error {
+        rs, ok := s.RootModule().Resources[resourceName]
+        if !ok {
+            return fmt.Errorf("resource %s not found in state", resourceName)
+        }
+
+        c, err := acctest.NewClient(t)
+        if err != nil {
+            return err
+        }
+        return c.DeleteDev(context.Background(), rs.Primary.ID)
+    }
+}
+
+// A Read that finds the group gone warns and removes it from state, so
+// Terraform plans to re-create it.
+func TestDevResourceReadDisappeared(t *testing.T) {
+    r := resourcetest.New(t, devs.NewDevResource, nil)
+    ctx := context.Background()
+    if err := r.Client.DeleteDevOps(ctx, "DDDDD"); err != nil {
+        t.Fatal(err)
+    }
+    if err := r.Client.DeleteDev(ctx, "BBBBB"); err != nil {
+        t.Fatal(err)
+    }
+
+    resp := r.Read(t, resourcetest.Attrs{"id": "BBBBB", "name": "Platform", "engineers": []string{"AAAAA"}})
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("unexpected error: %v", resp.Diagnostics)
+    }
+    warnings := resp.Diagnostics.Warnings()
+    if len(warnings) != 1 || warnings[0].Summary() != "Dev Not Found" {
+        t.Errorf("warnings = %v, want one %q", warnings, "Dev Not Found")
+    }
+    if !resp.State.Raw.IsNull() {
+        t.Errorf("state = %v, want the Dev group removed so it is re-created", resp.State.Raw)
+    }
+}
@@ -3,7 +3,9 @@ package engineers
 import (
 	"context"
 
+	"terraform-provider-devops/internal/provider/apidiag"
 	"terraform-provider-devops/internal/provider/client"
+	"terraform-provider-devops/internal/provider/providermeta"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -69,19 +71,22 @@ func (d *engineerDataSource) Configure(_ context.Context, req datasource.Configu
 
 // Read refreshes the Terraform state with the latest data.
 func (d *engineerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state EngineerDataSourceModel
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
 
-	engineers, err := d.client.GetEngineers()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read HashiCups Engineers",
-			err.Error(),
-		)
-		return
-	}
+	var state EngineerDataSourceModel
 
 	// Map response body to model
-	for _, engineer := range engineers {
+	for engineer, err := range d.client.ListEngineers(ctx) {
+		if err != nil {
+			apidiag.AddDataSourceError(
+				&resp.Diagnostics,
+				"Unable to Read HashiCups Engineers",
+				"",
+				err,
+			)
+			return
+		}
+
 		engineerState := engineersModel{
 			ID:    types.StringValue(engineer.ID),
 			Name:  types.StringValue(engineer.Name),
@@ -115,9 +120,3 @@ type engineersModel struct {
 type EngineersInfoModel struct {
 	ID types.String `tfsdk:"id"`
 }
-
-type engineerResourceModel struct {
-	ID    types.String `tfsdk:"id"`
-	Name  types.String `tfsdk:"name"`
-	Email types.String `tfsdk:"email"`
-}
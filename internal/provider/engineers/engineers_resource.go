@@ -3,9 +3,16 @@ package engineers
 import (
 	"context"
 	"fmt"
-	"strings"
+
+	"terraform-provider-devops/internal/provider/apidiag"
 	"terraform-provider-devops/internal/provider/client"
+	"terraform-provider-devops/internal/provider/concurrency"
+	"terraform-provider-devops/internal/provider/operation"
+	"terraform-provider-devops/internal/provider/providermeta"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -33,7 +40,7 @@ func (r *EngineerResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 // Schema defines the schema for the resource.
-func (r *EngineerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *EngineerResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -46,14 +53,21 @@ func (r *EngineerResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Required: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": operation.Block(ctx),
+		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *EngineerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	var plan engineerResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Create(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -64,12 +78,15 @@ func (r *EngineerResource) Create(ctx context.Context, req resource.CreateReques
 		Email: plan.Email.ValueString(),
 	}
 
-	createdEngineer, err := r.client.CreateEngineer(engineer)
+	createdEngineer, version, err := r.client.Engineers.CreateVersioned(ctx, engineer)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		apidiag.AddError(
+			&resp.Diagnostics,
 			"Error creating Engineer",
-			"Could not create order, unexpected error: "+err.Error(),
+			"Could not create Engineer, unexpected error: ",
+			err,
+			engineerAPIFields,
 		)
 
 		return
@@ -84,49 +101,79 @@ func (r *EngineerResource) Create(ctx context.Context, req resource.CreateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
+	resp.Diagnostics.Append(concurrency.SetVersion(ctx, resp.Private, version)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *EngineerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	var state engineerResourceModel
 
 	diags := req.State.Get(ctx, &state)
 
 	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Read(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	engineer, err := r.client.GetEngineer(state.ID.ValueString())
+	engineer, version, err := r.client.Engineers.GetVersioned(ctx, state.ID.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		// If the Engineer is gone, remove it from state (resource drift) so
+		// Terraform plans to re-create it.
+		if client.IsNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"Engineer Not Found",
+				fmt.Sprintf("Engineer %s no longer exists and has been removed from state; Terraform will plan to re-create it.", state.ID.ValueString()),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
 			"Error Reading Engineer",
-			"Could not read Engineer: "+err.Error(),
+			"Could not read Engineer: ",
+			err,
+			nil,
 		)
 
 		return
 	}
 
-	state.ID = types.StringValue(engineer.ID)
-	state.Name = types.StringValue(engineer.Name)
-	state.Email = types.StringValue(engineer.Email)
+	prior := state
+	state = engineerModelFrom(engineer)
+	state.Timeouts = prior.Timeouts
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
+	resp.Diagnostics.Append(concurrency.SetReadVersion(ctx, resp.Private, version)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	// Retrieve values from plan
 	var plan engineerResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Update(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Load current state to get the persisted ID (plan.ID may be unknown during update)
+	var state engineerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	version, diags := concurrency.Version(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -136,62 +183,88 @@ func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateReques
 		Name:  plan.Name.ValueString(),
 		Email: plan.Email.ValueString(),
 	}
-	reqEngineer.ID = plan.ID.ValueString()
+	reqEngineer.ID = state.ID.ValueString()
 
-	// Update existing order
-	_, err := r.client.UpdateEngineer(plan.ID.ValueString(), reqEngineer)
+	// Update existing engineer by ID from state, unless it changed since it was last read
+	_, err := r.client.Engineers.UpdateIfMatch(ctx, state.ID.ValueString(), reqEngineer, version)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if concurrency.IsConflict(err) && r.addConflictError(ctx, &resp.Diagnostics, "Conflicting Update to Engineer", state, err) {
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
 			"Error Updating Engineer",
-			"Could not update Engineer, unexpected error: "+err.Error(),
+			"Could not update Engineer, unexpected error: ",
+			err,
+			engineerAPIFields,
 		)
 		return
 	}
 
 	// Fetch updated items from GetOrder as UpdateOrder items are not
 	// populated.
-	engineer, err := r.client.GetEngineer(plan.ID.ValueString())
+	engineer, version, err := r.client.Engineers.GetVersioned(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		apidiag.AddError(
+			&resp.Diagnostics,
 			"Error Reading Engineer",
-			"Could not read Engineer ID "+plan.ID.ValueString()+": "+err.Error(),
+			"Could not read Engineer ID "+state.ID.ValueString()+": ",
+			err,
+			nil,
 		)
 		return
 	}
 
-	// Update resource state with updated items and timestamp
-	plan.ID = types.StringValue(engineer.ID)
-	plan.Name = types.StringValue(engineer.Name)
-	plan.Email = types.StringValue(engineer.Email)
+	// Update resource state with updated items
+	planned := plan
+	plan = engineerModelFrom(engineer)
+	plan.Timeouts = planned.Timeouts
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(concurrency.SetVersion(ctx, resp.Private, version)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	// Retrieve values from state
 	var state engineerResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	ctx, cancel := operation.Delete(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version, diags := concurrency.Version(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete existing engineer
-	err := r.client.DeleteEngineer(state.ID.ValueString())
+	// Delete existing engineer, unless it changed since it was last read
+	err := r.client.Engineers.DeleteIfMatch(ctx, state.ID.ValueString(), version)
 	if err != nil {
 		// If backend returns 404, treat as already deleted
-		if strings.Contains(err.Error(), "status: 404") {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError(
+		if concurrency.IsConflict(err) && r.addConflictError(ctx, &resp.Diagnostics, "Conflicting Delete of Engineer", state, err) {
+			return
+		}
+		apidiag.AddError(
+			&resp.Diagnostics,
 			"Error Deleting Engineer: "+state.ID.ValueString(),
-			"Could not delete Engineer, unexpected error: "+err.Error(),
+			"Could not delete Engineer, unexpected error: ",
+			err,
+			nil,
 		)
 		return
 	}
@@ -214,3 +287,42 @@ func (r *EngineerResource) Configure(ctx context.Context, req resource.Configure
 
 	r.client = client
 }
+
+// addConflictError re-reads the engineer after a write failed with err and
+// reports what changed remotely; see concurrency.AddConflictError.
+func (r *EngineerResource) addConflictError(ctx context.Context, diags *diag.Diagnostics, summary string, prior engineerResourceModel, err error) bool {
+	object := "Engineer " + prior.ID.ValueString()
+	engineer, getErr := r.client.GetEngineer(ctx, prior.ID.ValueString())
+	switch {
+	case client.IsNotFound(getErr):
+		return concurrency.AddConflictError(diags, summary, object, prior, nil, err)
+	case getErr != nil:
+		return false
+	}
+	remote := engineerModelFrom(engineer)
+	remote.Timeouts = prior.Timeouts
+	return concurrency.AddConflictError(diags, summary, object, prior, remote, err)
+}
+
+// engineerModelFrom maps an API engineer to the resource model.
+func engineerModelFrom(engineer *client.Engineer) engineerResourceModel {
+	return engineerResourceModel{
+		ID:    types.StringValue(engineer.ID),
+		Name:  types.StringValue(engineer.Name),
+		Email: types.StringValue(engineer.Email),
+	}
+}
+
+// engineerResourceModel maps the resource schema data.
+type engineerResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Email    types.String   `tfsdk:"email"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// engineerAPIFields maps DOB API validation error fields to dob_engineer attributes.
+var engineerAPIFields = apidiag.Fields{
+	"name":  path.Root("name"),
+	"email": path.Root("email"),
+}
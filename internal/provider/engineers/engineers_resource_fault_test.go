@@ -0,0 +1,64 @@
+package engineers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-devops/internal/faultinject"
+	"terraform-provider-devops/internal/provider/engineers"
+	"terraform-provider-devops/internal/resourcetest"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEngineerResourceFaults(t *testing.T) {
+	fixture := resourcetest.Fixture{
+		Create: resourcetest.Attrs{"id": tftypes.UnknownValue, "name": "Bob", "email": "bob@example.com"},
+		State:  resourcetest.Attrs{"id": "AAAAA", "name": "Ann", "email": "ann@example.com"},
+		Update: resourcetest.Attrs{"id": tftypes.UnknownValue, "name": "Anne", "email": "anne@example.com"},
+	}
+	resourcetest.RunFaults(t, engineers.NewEngineerResource, fixture, map[string]resourcetest.FaultCase{
+		"create server error":         {Op: resourcetest.Create, Method: http.MethodPost, Path: "/engineers", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error creating Engineer"},
+		"create server error applied": {Op: resourcetest.Create, Method: http.MethodPost, Path: "/engineers", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError, Forward: true}, Summary: "Error creating Engineer"},
+		"create dropped":              {Op: resourcetest.Create, Method: http.MethodPost, Path: "/engineers", Fault: faultinject.Fault{Drop: true}, Summary: "Error creating Engineer"},
+		"create truncated body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/engineers", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error creating Engineer"},
+		"create corrupted body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/engineers", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error creating Engineer"},
+
+		"update fails":   {Op: resourcetest.Update, Method: http.MethodPut, Path: "/engineers/AAAAA", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Updating Engineer"},
+		"update dropped": {Op: resourcetest.Update, Method: http.MethodPut, Path: "/engineers/AAAAA", Fault: faultinject.Fault{Drop: true}, Summary: "Error Updating Engineer"},
+		// The update goes through but reading it back fails: the prior state
+		// must be kept rather than the unconfirmed plan.
+		"update read back fails":     {Op: resourcetest.Update, Method: http.MethodGet, Path: "/engineers/id/AAAAA", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error Reading Engineer"},
+		"update read back truncated": {Op: resourcetest.Update, Method: http.MethodGet, Path: "/engineers/id/AAAAA", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading Engineer"},
+
+		"read server error":     {Op: resourcetest.Read, Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Reading Engineer", Detail: "500"},
+		"read connection reset": {Op: resourcetest.Read, Fault: faultinject.Fault{ResetBody: true}, Summary: "Error Reading Engineer", Detail: "connection reset"},
+		"read truncated body":   {Op: resourcetest.Read, Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading Engineer", Detail: "/engineers/id/AAAAA"},
+	})
+}
+
+func TestEngineerResourceCreateTimeout(t *testing.T) {
+	ft := &faultinject.Transport{}
+	ft.Script(http.MethodPost, "/engineers", faultinject.Fault{Latency: time.Minute})
+	r := resourcetest.New(t, engineers.NewEngineerResource, ft)
+
+	start := time.Now()
+	resp := r.Create(t, resourcetest.Attrs{
+		"id":       tftypes.UnknownValue,
+		"name":     "Bob",
+		"email":    "bob@example.com",
+		"timeouts": map[string]string{"create": "100ms"},
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("want error diagnostic")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "timeouts block") {
+		t.Errorf("detail = %q, want it to point at the timeouts block", detail)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("create gave up after %s, want after its 100ms timeout", elapsed)
+	}
+}
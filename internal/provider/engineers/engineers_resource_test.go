@@ -1,44 +1,89 @@
 package engineers_test
 
 import (
-    "testing"
+	"context"
+	"fmt"
+	"testing"
 
-    "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"terraform-provider-devops/internal/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccEngineerResource(t *testing.T) {
-    resource.Test(t, resource.TestCase{
-        ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-        Steps: []resource.TestStep{
-            // Create and Read testing
-            {
-                Config: providerConfig + `
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acctest.ProviderConfig() + `
 resource "dob_engineer" "test" {
     name = "Test User 123"
     email = "testuser123@liatrio.com"
 }
 `,
-                Check: resource.ComposeAggregateTestCheckFunc(
-                    resource.TestCheckResourceAttr("dob_engineer.test", "name", "Test User 123"),
-                    resource.TestCheckResourceAttr("dob_engineer.test", "email", "testuser123@liatrio.com"),
-                    resource.TestCheckResourceAttrSet("dob_engineer.test", "id"),
-                ),
-            },
-            // Update and Read testing
-            {
-                Config: providerConfig + `
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dob_engineer.test", "name", "Test User 123"),
+					resource.TestCheckResourceAttr("dob_engineer.test", "email", "testuser123@liatrio.com"),
+					resource.TestCheckResourceAttrSet("dob_engineer.test", "id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: acctest.ProviderConfig() + `
 resource "dob_engineer" "test" {
-    name = "Test User 123"
-    email = "testuser123@liatrio.com"
+    name = "Test User 456"
+    email = "testuser456@liatrio.com"
 }
 `,
-                Check: resource.ComposeAggregateTestCheckFunc(
-                    resource.TestCheckResourceAttr("dob_engineer.test", "name", "Test User 123"),
-                    resource.TestCheckResourceAttr("dob_engineer.test", "email", "testuser123@liatrio.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dob_engineer.test", "name", "Test User 456"),
+					resource.TestCheckResourceAttr("dob_engineer.test", "email", "testuser456@liatrio.com"),
 					resource.TestCheckResourceAttrSet("dob_engineer.test", "id"),
-                ),
-            },
-            // Delete testing automatically occurs in TestCase
-        },
-    })
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEngineerResource_disappears(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig() + `
+resource "dob_engineer" "test" {
+    name  = "Disappearing Engineer"
+    email = "disappearing@liatrio.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("dob_engineer.test", "id"),
+					testAccDeleteEngineerOutOfBand(t, "dob_engineer.test"),
+				),
+				// The refresh after apply finds the engineer missing and plans
+				// to re-create it.
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccDeleteEngineerOutOfBand deletes the engineer behind resourceName
+// directly through the API, simulating a deletion outside Terraform.
+func testAccDeleteEngineerOutOfBand(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		c, err := acctest.NewClient(t)
+		if err != nil {
+			return err
+		}
+		return c.DeleteEngineer(context.Background(), rs.Primary.ID)
+	}
 }
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// skipHint closes every health check diagnostic.
+const skipHint = "\n\nSet skip_health_check = true in the dob provider block to skip this check, for example for plans without network access."
+
+// validateEndpoint reports a missing or malformed endpoint. An unknown
+// endpoint is checked once it is known.
+func validateEndpoint(endpoint types.String, diags *diag.Diagnostics) {
+	if endpoint.IsUnknown() {
+		return
+	}
+	if endpoint.IsNull() {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Missing DOB API Endpoint",
+			"The dob provider needs the base URL of the DOB API, such as \"https://dob.example.com\". "+
+				"Set endpoint in the provider block, the DOB_ENDPOINT environment variable or a profile of the DOB configuration file.",
+		)
+		return
+	}
+	if err := client.ValidateEndpoint(endpoint.ValueString()); err != nil {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid DOB API Endpoint",
+			fmt.Sprintf("The dob provider cannot use the configured endpoint: %s.", err),
+		)
+	}
+}
+
+// addHealthError reports a failed client.CheckHealth against endpoint with
+// what most likely needs fixing.
+func addHealthError(diags *diag.Diagnostics, endpoint string, err error) {
+	var healthErr *client.HealthError
+	if !errors.As(err, &healthErr) {
+		diags.AddError("Unable to Reach DOB API", fmt.Sprintf("Checking %s failed: %s%s", endpoint, err, skipHint))
+		return
+	}
+
+	var summary, hint string
+	switch healthErr.Problem {
+	case client.HealthDNS:
+		summary = "DOB API Host Not Found"
+		hint = "The host name in endpoint does not resolve. Check it for typos, and that this machine can resolve it, for example through a VPN."
+	case client.HealthTimeout:
+		summary = "DOB API Did Not Respond"
+		hint = fmt.Sprintf("No answer arrived within %s. Check that endpoint names the right host and port, and that no firewall drops the connection.",
+			client.DefaultHealthCheckTimeout)
+	case client.HealthTLS:
+		summary = "TLS Handshake with DOB API Failed"
+		hint = "Check that endpoint uses the scheme the API serves (http or https). If the API's certificate is issued by a private CA, " +
+			"trust it with ca_cert_file or ca_cert_pem; if the API requires mutual TLS, set client_cert and client_key."
+	case client.HealthAuth:
+		summary = "Authentication Failed"
+		hint = "The DOB API did not accept the provider's credentials. Check the token, username/password, credential_process or oauth2 settings of the dob provider."
+	case client.HealthWrongService:
+		summary = "Endpoint Is Not a DOB API"
+		hint = "Something answered at endpoint, but not like a DOB API. Check the host, port and path prefix of endpoint."
+	case client.HealthUnavailable:
+		summary = "DOB API Unavailable"
+		hint = "The DOB API answered with a server error. Try again later, or contact its operators if the problem persists."
+	default:
+		summary = "Unable to Reach DOB API"
+		hint = "No connection could be made. Check that endpoint names the right host and port, or socket, and that the API is running."
+	}
+	diags.AddAttributeError(
+		path.Root("endpoint"),
+		summary,
+		fmt.Sprintf("%s\n\nChecking %s failed: %s%s", hint, endpoint, err, skipHint),
+	)
+}
@@ -0,0 +1,61 @@
+// Package operation bounds the create, read, update and delete operations
+// of resources by the timeouts block of their configuration:
+//
+//	resource "dob_devops" "platform" {
+//	  ...
+//	  timeouts {
+//	    create = "45m"
+//	  }
+//	}
+//
+// The deadline applies to the whole operation, including every retry of its
+// API requests; request_timeout of the provider bounds each single attempt.
+package operation
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// DefaultTimeout bounds an operation whose timeout is not configured.
+const DefaultTimeout = 20 * time.Minute
+
+// Block returns the timeouts block of the resource schemas.
+func Block(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true})
+}
+
+// Create returns ctx bounded by the create timeout of t.
+func Create(ctx context.Context, t timeouts.Value, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Create, diags)
+}
+
+// Read returns ctx bounded by the read timeout of t.
+func Read(ctx context.Context, t timeouts.Value, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Read, diags)
+}
+
+// Update returns ctx bounded by the update timeout of t.
+func Update(ctx context.Context, t timeouts.Value, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Update, diags)
+}
+
+// Delete returns ctx bounded by the delete timeout of t.
+func Delete(ctx context.Context, t timeouts.Value, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Delete, diags)
+}
+
+// withTimeout bounds ctx by the timeout get returns. An invalid timeout is
+// reported through diags and replaced by DefaultTimeout.
+func withTimeout(ctx context.Context, get func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	timeout, d := get(ctx, DefaultTimeout)
+	diags.Append(d...)
+	if d.HasError() {
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,61 @@
+package operation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsValue returns a timeouts block setting create to create.
+func timeoutsValue(create string) timeouts.Value {
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+	return timeouts.Value{Object: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"create": types.StringValue(create),
+		"read":   types.StringNull(),
+		"update": types.StringNull(),
+		"delete": types.StringNull(),
+	})}
+}
+
+func TestDeadlines(t *testing.T) {
+	cases := map[string]struct {
+		timeouts  timeouts.Value
+		op        func(context.Context, timeouts.Value, *diag.Diagnostics) (context.Context, context.CancelFunc)
+		want      time.Duration
+		wantError bool
+	}{
+		"configured":      {timeoutsValue("45m"), Create, 45 * time.Minute, false},
+		"other operation": {timeoutsValue("45m"), Delete, DefaultTimeout, false},
+		"no block":        {timeouts.Value{}, Read, DefaultTimeout, false},
+		"invalid":         {timeoutsValue("soon"), Create, DefaultTimeout, true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			start := time.Now()
+			ctx, cancel := tc.op(context.Background(), tc.timeouts, &diags)
+			defer cancel()
+
+			if diags.HasError() != tc.wantError {
+				t.Errorf("diagnostics = %v, want error %t", diags, tc.wantError)
+			}
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("context has no deadline")
+			}
+			if got := deadline.Sub(start); got < tc.want || got > tc.want+time.Second {
+				t.Errorf("deadline in %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
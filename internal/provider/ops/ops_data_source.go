@@ -3,7 +3,10 @@ package ops
 import (
 	"context"
 
+	"terraform-provider-devops/internal/provider/apidiag"
+	"terraform-provider-devops/internal/provider/capability"
 	"terraform-provider-devops/internal/provider/client"
+	"terraform-provider-devops/internal/provider/providermeta"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -39,8 +42,9 @@ func (d *opsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id":   schema.StringAttribute{Computed: true},
-						"name": schema.StringAttribute{Computed: true},
+						"id":          schema.StringAttribute{Computed: true},
+						"name":        schema.StringAttribute{Computed: true},
+						"description": schema.StringAttribute{Computed: true},
 						"engineers": schema.ListAttribute{
 							Computed:    true,
 							ElementType: types.StringType,
@@ -72,25 +76,33 @@ func (d *opsDataSource) Configure(_ context.Context, req datasource.ConfigureReq
 
 // Read refreshes the Terraform state with the latest data.
 func (d *opsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = providermeta.Context(ctx, req.ProviderMeta)
+
 	var state opsDataSourceModel
 
-	devs, err := d.client.GetOps()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read Ops groups",
-			err.Error(),
-		)
-		return
-	}
+	for dv, err := range d.client.ListOps(ctx) {
+		if err != nil {
+			apidiag.AddDataSourceError(
+				&resp.Diagnostics,
+				"Unable to read Ops groups",
+				"",
+				err,
+			)
+			return
+		}
 
-	for _, dv := range devs {
 		dvm := opsDSModel{
-			ID:   types.StringValue(dv.ID),
-			Name: types.StringValue(dv.Name),
+			ID:          types.StringValue(dv.ID),
+			Name:        types.StringValue(dv.Name),
+			Description: capability.OptionalString(dv.Description),
 		}
 
-		// dv.Engineers is []string of engineer IDs; map to Terraform list(string)
-		engList, diags := types.ListValueFrom(ctx, types.StringType, dv.Engineers)
+		// Convert engineers (objects) to a list of engineer IDs
+		engineerIDs := make([]string, 0, len(dv.Engineers))
+		for _, eng := range dv.Engineers {
+			engineerIDs = append(engineerIDs, eng.ID)
+		}
+		engList, diags := types.ListValueFrom(ctx, types.StringType, engineerIDs)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -114,9 +126,10 @@ type opsDataSourceModel struct {
 
 // opsModel maps Ops schema data.
 type opsDSModel struct {
-	ID        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Engineers types.List   `tfsdk:"engineers"`
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Engineers   types.List   `tfsdk:"engineers"`
 }
 
 // opsInfoModel maps Ops info data
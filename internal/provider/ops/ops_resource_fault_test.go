@@ -0,0 +1,38 @@
+package ops_test
+
+import (
+	"net/http"
+	"testing"
+
+	"terraform-provider-devops/internal/faultinject"
+	"terraform-provider-devops/internal/provider/ops"
+	"terraform-provider-devops/internal/resourcetest"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOpsResourceFaults(t *testing.T) {
+	fixture := resourcetest.Fixture{
+		Create: resourcetest.Attrs{"id": tftypes.UnknownValue, "name": "Run", "engineers": []string{"AAAAA"}},
+		State:  resourcetest.Attrs{"id": "CCCCC", "name": "Platform", "engineers": []string{"AAAAA"}},
+		Update: resourcetest.Attrs{"id": tftypes.UnknownValue, "name": "On call", "engineers": []string{"AAAAA"}},
+	}
+	resourcetest.RunFaults(t, ops.NewOpsResource, fixture, map[string]resourcetest.FaultCase{
+		"create server error":         {Op: resourcetest.Create, Method: http.MethodPost, Path: "/op", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error creating Ops"},
+		"create server error applied": {Op: resourcetest.Create, Method: http.MethodPost, Path: "/op", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError, Forward: true}, Summary: "Error creating Ops"},
+		"create dropped":              {Op: resourcetest.Create, Method: http.MethodPost, Path: "/op", Fault: faultinject.Fault{Drop: true}, Summary: "Error creating Ops"},
+		"create truncated body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/op", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error creating Ops"},
+		"create corrupted body":       {Op: resourcetest.Create, Method: http.MethodPost, Path: "/op", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error creating Ops"},
+
+		"update fails":   {Op: resourcetest.Update, Method: http.MethodPut, Path: "/op/CCCCC", Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Updating Ops"},
+		"update dropped": {Op: resourcetest.Update, Method: http.MethodPut, Path: "/op/CCCCC", Fault: faultinject.Fault{Drop: true}, Summary: "Error Updating Ops"},
+		// The update goes through but reading it back fails: the prior state
+		// must be kept rather than the unconfirmed plan.
+		"update read back fails":     {Op: resourcetest.Update, Method: http.MethodGet, Path: "/op/id/CCCCC", Fault: faultinject.Fault{Corrupt: true}, Summary: "Error Reading Ops"},
+		"update read back truncated": {Op: resourcetest.Update, Method: http.MethodGet, Path: "/op/id/CCCCC", Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading Ops"},
+
+		"read server error":     {Op: resourcetest.Read, Fault: faultinject.Fault{StatusCode: http.StatusInternalServerError}, Summary: "Error Reading Ops", Detail: "500"},
+		"read connection reset": {Op: resourcetest.Read, Fault: faultinject.Fault{ResetBody: true}, Summary: "Error Reading Ops", Detail: "connection reset"},
+		"read truncated body":   {Op: resourcetest.Read, Fault: faultinject.Fault{Truncate: 5}, Summary: "Error Reading Ops", Detail: "/op/id/CCCCC"},
+	})
+}
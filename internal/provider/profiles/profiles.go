@@ -0,0 +1,132 @@
+// Package profiles reads the DOB configuration file, ~/.config/dob/config,
+// whose sections are named profiles of provider settings:
+//
+//	[default]
+//	endpoint = https://dob.example.com
+//
+//	[staging]
+//	endpoint = https://dob.staging.example.com
+//	token    = s3cr3t
+//
+// Keys are the names of provider attributes. Lines starting with # or ;
+// are comments.
+package profiles
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultProfile is the profile used when none is selected.
+	DefaultProfile = "default"
+	// FileEnvVar names the environment variable that overrides the path of
+	// the configuration file.
+	FileEnvVar = "DOB_CONFIG_FILE"
+)
+
+// DefaultPath returns the path of the configuration file: $DOB_CONFIG_FILE
+// if set, else dob/config in $XDG_CONFIG_HOME or ~/.config.
+func DefaultPath() (string, error) {
+	if p := os.Getenv(FileEnvVar); p != "" {
+		return p, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locating the DOB configuration file: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "dob", "config"), nil
+}
+
+// File is a parsed configuration file.
+type File struct {
+	Path     string
+	profiles map[string]map[string]string
+}
+
+// Load reads the configuration file at path. A missing file is not an
+// error; it has no profiles.
+func Load(path string) (*File, error) {
+	f := &File{Path: path, profiles: map[string]map[string]string{}}
+	r, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading DOB configuration file: %w", err)
+	}
+	defer r.Close()
+	if f.profiles, err = Parse(r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Parse reads profiles from r. Settings repeated within a profile, or in
+// repeated sections of it, take their last value.
+func Parse(r io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+		case line[0] == '[':
+			name, ok := strings.CutSuffix(line[1:], "]")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("line %d: malformed profile header %q", n, line)
+			}
+			if profiles[name] == nil {
+				profiles[name] = map[string]string{}
+			}
+			current = profiles[name]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: want key = value, got %q", n, line)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: setting outside of a [profile] section", n)
+		}
+		current[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+	return profiles, s.Err()
+}
+
+// unquote strips one pair of matching double or single quotes.
+func unquote(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
+// Profile returns the settings of the named profile.
+func (f *File) Profile(name string) (map[string]string, bool) {
+	p, ok := f.profiles[name]
+	return p, ok
+}
+
+// Names returns the names of the file's profiles in sorted order.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.profiles))
+	for name := range f.profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
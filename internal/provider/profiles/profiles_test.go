@@ -0,0 +1,83 @@
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(`
+# Local development
+[default]
+endpoint = http://localhost:8080
+
+[ staging ]
+endpoint = "https://dob.staging.example.com"
+token=s3cr3t
+; later sections extend earlier ones
+[default]
+skip_health_check = true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]string{
+		"default": {"endpoint": "http://localhost:8080", "skip_health_check": "true"},
+		"staging": {"endpoint": "https://dob.staging.example.com", "token": "s3cr3t"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"endpoint = x\n":        "line 1: setting outside of a [profile] section",
+		"[default\n":            "line 1: malformed profile header",
+		"[]\n":                  "line 1: malformed profile header",
+		"[default]\nendpoint\n": "line 2: want key = value",
+	} {
+		if _, err := Parse(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) = %v, want error containing %q", input, err, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	f, err := Load(filepath.Join(dir, "missing"))
+	if err != nil || len(f.Names()) != 0 {
+		t.Fatalf("Load(missing) = %v, %v, want an empty file", f.Names(), err)
+	}
+
+	path := filepath.Join(dir, "config")
+	if err := os.WriteFile(path, []byte("[b]\n[a]\ntoken = t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := f.Names(); !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("Names() = %v", names)
+	}
+	if p, ok := f.Profile("a"); !ok || p["token"] != "t" {
+		t.Errorf("Profile(a) = %v, %v", p, ok)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv(FileEnvVar, "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got, _ := DefaultPath(); got != filepath.Join("/xdg", "dob", "config") {
+		t.Errorf("DefaultPath() = %q", got)
+	}
+	t.Setenv(FileEnvVar, "/etc/dob.conf")
+	if got, _ := DefaultPath(); got != "/etc/dob.conf" {
+		t.Errorf("DefaultPath() with %s = %q", FileEnvVar, got)
+	}
+}
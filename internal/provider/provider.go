@@ -5,12 +5,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"terraform-provider-devops/internal/provider/client"
 	"terraform-provider-devops/internal/provider/devops"
 	"terraform-provider-devops/internal/provider/devs"
 	"terraform-provider-devops/internal/provider/engineers"
 	"terraform-provider-devops/internal/provider/ops"
+	"terraform-provider-devops/internal/provider/providermeta"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -20,7 +23,10 @@ import (
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
-var _ provider.Provider = &DOBProvider{}
+var (
+	_ provider.Provider               = &DOBProvider{}
+	_ provider.ProviderWithMetaSchema = &DOBProvider{}
+)
 
 // DOBProvider defines the provider implementation.
 type DOBProvider struct {
@@ -28,11 +34,51 @@ type DOBProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// clientOptions are applied to the API client after the options derived
+	// from the provider configuration.
+	clientOptions []client.Option
 }
 
 // DOBProviderModel describes the provider data model.
 type DOBProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+	Endpoints        types.List   `tfsdk:"endpoints"`
+	RetryMaxAttempts types.Int64  `tfsdk:"retry_max_attempts"`
+	RetryMaxBackoff  types.String `tfsdk:"retry_max_backoff"`
+	RequestTimeout   types.String `tfsdk:"request_timeout"`
+	Token            types.String `tfsdk:"token"`
+	Username         types.String `tfsdk:"username"`
+	Password         types.String `tfsdk:"password"`
+	OAuth2           *oauth2Model `tfsdk:"oauth2"`
+
+	CredentialProcess types.String `tfsdk:"credential_process"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	HTTPProxy          types.String `tfsdk:"http_proxy"`
+
+	LogMaskedFields types.List `tfsdk:"log_masked_fields"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	CacheDir   types.String `tfsdk:"cache_dir"`
+	PrimeReads types.Bool   `tfsdk:"prime_reads"`
+
+	SkipHealthCheck types.Bool `tfsdk:"skip_health_check"`
+
+	Profile types.String `tfsdk:"profile"`
+}
+
+// oauth2Model describes the oauth2 provider block.
+type oauth2Model struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
 }
 
 func (p *DOBProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -45,13 +91,165 @@ func (p *DOBProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "Example provider attribute",
+				MarkdownDescription: "Base URL of the DOB API, such as `https://dob.example.com`. " +
+					"An optional path prefix is kept, such as `https://example.com/dob`. " +
+					"An API listening on a Unix domain socket is reached through the socket's path, such as `unix:///run/dob.sock`, " +
+					"followed by an optional path prefix after a colon, such as `unix:///run/dob.sock:/dob`.",
+				Optional: true,
+			},
+			"endpoints": schema.ListAttribute{
+				MarkdownDescription: fmt.Sprintf("Base URLs of further deployments of the same DOB API, such as in other regions, "+
+					"in order of preference after `endpoint`. When an endpoint fails with a connection error or a server error, "+
+					"the provider avoids it for %s and switches to the next one, repeating reads there right away; "+
+					"writes are not repeated, and requests stay on the endpoint that took a write for a while afterwards.",
+					client.EndpointCooldown),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Total number of attempts for a request that fails with a retryable error "+
+					"(HTTP 429, 502, 503, 504 or a connection error on an idempotent request). "+
+					"A 500 is not retried, since it reports a failure inside the API that repeating the request rarely avoids. "+
+					"Set to `1` to disable retries. Defaults to `%d`.", client.DefaultMaxAttempts),
+				Optional: true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Longest delay between two attempts, as a Go duration string such as `\"10s\"`. "+
+					"A `Retry-After` header from the API is honored up to this limit. Defaults to `\"%s\"`.", client.DefaultMaxBackoff),
+				Optional: true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Longest time a single attempt of an API request may take, as a Go duration string such as `\"30s\"`. "+
+					"Operations as a whole, including retries, are bounded by the `timeouts` block of each resource instead. Defaults to `\"%s\"`.", client.DefaultRequestTimeout),
+				Optional: true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token sent in the `Authorization` header of every API request. " +
+					"Conflicts with `username`/`password`, `credential_process` and `oauth2`.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "Command that prints a short-lived bearer token, such as a client of a token broker. " +
+					"It is split into arguments like a shell would but run without one, and must print a JSON object such as " +
+					"`{\"token\": \"...\", \"expires_at\": \"2030-01-02T15:04:05Z\"}`, where `expires_at` is optional. " +
+					"Tokens are cached and the command runs again shortly before they expire or when the API rejects them. " +
+					"Conflicts with `token`, `username`/`password` and `oauth2`.",
+				Optional: true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username for HTTP basic authentication. Requires `password`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password for HTTP basic authentication. Requires `username`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM-encoded CA bundle trusted in addition to the system roots.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates trusted in addition to the system roots.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "Client certificate for mutual TLS, either PEM-encoded or a path to a PEM file. Requires `client_key`.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "Private key for `client_cert`, either PEM-encoded or a path to a PEM file.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the API server's TLS certificate. Only use this for local development.",
+				Optional:            true,
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP proxy for API requests. Overrides the `HTTP_PROXY`/`HTTPS_PROXY` environment variables.",
 				Optional:            true,
 			},
+			"log_masked_fields": schema.ListAttribute{
+				MarkdownDescription: fmt.Sprintf("JSON body fields and HTTP headers whose values are masked in provider logs, "+
+					"in addition to `[\"%s\"]` and credential headers such as `Authorization`, which are always masked. "+
+					"Request and response bodies are only logged at `TRACE` level, and bodies that are not JSON only by their size.",
+					strings.Join(client.DefaultMaskedLogFields, `", "`)),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of API requests the provider keeps in flight at once. "+
+					"Requests are additionally paced using the API's `X-RateLimit-Remaining` and `X-RateLimit-Reset` headers. "+
+					"Defaults to `%d`.", client.DefaultMaxConcurrentRequests),
+				Optional: true,
+			},
+			"cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory in which to keep API responses with their `ETag`/`Last-Modified` validators across runs. " +
+					"Cached responses are always revalidated with a conditional request and only reused when the API answers `304 Not Modified`. " +
+					"The files hold response bodies as the API sent them, without the masking applied to logs, so they contain personal data " +
+					"such as email addresses; they are readable by their owner only and never removed by the provider. " +
+					"Without it, responses are only cached for the lifetime of the provider process.",
+				Optional: true,
+			},
+			"prime_reads": schema.BoolAttribute{
+				MarkdownDescription: "Read each collection with a single list request the first time one of its objects is read, " +
+					"and serve further reads of its objects from that list, so refreshing many resources costs one request per " +
+					"collection page rather than one per object. Objects served from the list carry no version, so updates and " +
+					"deletes are guarded with `If-Match` against the version of the last individual read. Defaults to `false`.",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Profile of the DOB configuration file (`$DOB_CONFIG_FILE`, or `~/.config/dob/config`) to read settings from. " +
+					"Can also be set with the `DOB_PROFILE` environment variable. Defaults to `default`, which is only used if the file defines it. " +
+					"Settings in the provider block take precedence over environment variables such as `DOB_ENDPOINT` and `DOB_TOKEN`, " +
+					"which take precedence over the profile.",
+				Optional: true,
+			},
+			"skip_health_check": schema.BoolAttribute{
+				MarkdownDescription: fmt.Sprintf("Skip checking that the endpoint is reachable and accepts the credentials when the provider is configured, "+
+					"for example for plans without network access. The check takes at most %s; without it, the API version is "+
+					"negotiated on the first API request instead. Defaults to `false`.", client.DefaultHealthCheckTimeout),
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"oauth2": schema.SingleNestedBlock{
+				MarkdownDescription: "Obtain access tokens with the OAuth2 client credentials grant. " +
+					"Tokens are refreshed automatically before they expire.",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						MarkdownDescription: "URL of the OAuth2 token endpoint.",
+						Optional:            true,
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "OAuth2 client ID.",
+						Optional:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "OAuth2 client secret.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"scopes": schema.ListAttribute{
+						MarkdownDescription: "Scopes to request with the token.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
 
+// MetaSchema defines the provider_meta "dob" block modules can use to
+// identify themselves to the API.
+func (p *DOBProvider) MetaSchema(_ context.Context, _ provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	resp.Schema = providermeta.Schema()
+}
+
 func (p *DOBProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config DOBProviderModel
 	diags := req.Config.Get(ctx, &config)
@@ -60,8 +258,13 @@ func (p *DOBProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	// Configuration values are now available.
-	// if config.Endpoint.IsNull() { /* ... */ }
+	// Fill in settings from the environment and the selected profile, and
+	// point diagnostics about them at where they came from.
+	sources := resolveSettings(ctx, &config, &resp.Diagnostics)
+	defer func() { resp.Diagnostics = sources.annotate(resp.Diagnostics) }()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Initialize custom API client for data sources and resources
 	var endpointPtr *string
@@ -69,8 +272,28 @@ func (p *DOBProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		v := config.Endpoint.ValueString()
 		endpointPtr = &v
 	}
+	validateEndpoint(config.Endpoint, &resp.Diagnostics)
+
+	var opts []client.Option
+	for _, build := range clientOptionBuilders {
+		if opt := build(ctx, config, &resp.Diagnostics); opt != nil {
+			opts = append(opts, opt)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts = append(opts, client.WithUserAgent(client.UserAgent(p.version, req.TerraformVersion)))
+	// Without the health check, or before the endpoint is known, configuring
+	// the provider makes no API requests.
+	checkHealth := endpointPtr != nil && !config.SkipHealthCheck.ValueBool()
+	if !checkHealth {
+		opts = append(opts, client.WithDeferredNegotiation())
+	}
+	opts = append(opts, p.clientOptions...)
 
-	c, err := client.NewClient(endpointPtr)
+	c, err := client.NewClient(endpointPtr, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create API client",
@@ -79,6 +302,23 @@ func (p *DOBProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
+	if checkHealth {
+		if err := c.CheckHealth(ctx, client.DefaultHealthCheckTimeout); err != nil {
+			addHealthError(&resp.Diagnostics, *endpointPtr, err)
+			return
+		}
+
+		// Pick the API version and learn which optional features the server
+		// has, so resources can adapt to it.
+		if err := c.Negotiate(ctx); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to negotiate DOB API version",
+				err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.DataSourceData = c
 	resp.ResourceData = c
 }
@@ -109,3 +349,14 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// NewWithClientOptions is New for tests that need to adjust the API client,
+// for example to record or replay its traffic.
+func NewWithClientOptions(version string, opts ...client.Option) func() provider.Provider {
+	return func() provider.Provider {
+		return &DOBProvider{
+			version:       version,
+			clientOptions: opts,
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Package providermeta reads the provider_meta "dob" block through which
+// modules identify themselves to the DOB API:
+//
+//	terraform {
+//	  provider_meta "dob" {
+//	    module_name = "team-onboarding"
+//	  }
+//	}
+package providermeta
+
+import (
+	"context"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Schema returns the schema of the provider_meta "dob" block.
+func Schema() metaschema.Schema {
+	return metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"module_name": metaschema.StringAttribute{
+				MarkdownDescription: "Name of the module, sent to the DOB API with every request made for its resources and data sources.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// Context returns ctx with API requests attributed to the module named in
+// meta, the provider_meta of a resource or data source request. Without a
+// module name ctx is returned as is.
+func Context(ctx context.Context, meta tfsdk.Config) context.Context {
+	if meta.Raw.IsNull() {
+		return ctx
+	}
+	var name types.String
+	// Attribution is best effort; a malformed block only loses the name.
+	if diags := meta.GetAttribute(ctx, path.Root("module_name"), &name); diags.HasError() {
+		return ctx
+	}
+	return client.ContextWithModule(ctx, name.ValueString())
+}
@@ -0,0 +1,52 @@
+package providermeta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestContextForwardsModuleName(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(client.ModuleHeader)
+		_, _ = w.Write([]byte(`{"id":"A"}`))
+	}))
+	defer srv.Close()
+	c, err := client.NewClient(&srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	s := Schema()
+	typ := s.Type().TerraformType(ctx)
+	cases := map[string]struct {
+		meta tfsdk.Config
+		want string
+	}{
+		"module name": {tfsdk.Config{Schema: s, Raw: tftypes.NewValue(typ, map[string]tftypes.Value{
+			"module_name": tftypes.NewValue(tftypes.String, "onboarding"),
+		})}, "onboarding"},
+		"no module name": {tfsdk.Config{Schema: s, Raw: tftypes.NewValue(typ, map[string]tftypes.Value{
+			"module_name": tftypes.NewValue(tftypes.String, nil),
+		})}, ""},
+		"no provider_meta": {tfsdk.Config{}, ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := c.GetEngineer(Context(ctx, tc.meta), "A"); err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("module header = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"terraform-provider-devops/internal/provider/profiles"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ProfileEnvVar names the environment variable that selects the profile
+// when the provider block does not.
+const ProfileEnvVar = "DOB_PROFILE"
+
+// externalSetting is a provider attribute that can also be set through an
+// environment variable or a profile of the DOB configuration file.
+type externalSetting struct {
+	attribute string
+	envVar    string
+	// group names settings that are only taken together from one source,
+	// so that credentials or endpoints from different places are never
+	// combined.
+	group string
+	// field returns the model's *types.String, *types.Bool or *types.List
+	// of strings, which is written comma-separated outside the block.
+	field func(*DOBProviderModel) any
+}
+
+// externalSettings lists the attributes settable outside the provider
+// block. Keys in profiles are the attribute names.
+var externalSettings = []externalSetting{
+	{"endpoint", "DOB_ENDPOINT", "endpoints", func(m *DOBProviderModel) any { return &m.Endpoint }},
+	{"endpoints", "DOB_ENDPOINTS", "endpoints", func(m *DOBProviderModel) any { return &m.Endpoints }},
+	{"token", "DOB_TOKEN", "credentials", func(m *DOBProviderModel) any { return &m.Token }},
+	{"username", "DOB_USERNAME", "credentials", func(m *DOBProviderModel) any { return &m.Username }},
+	{"password", "DOB_PASSWORD", "credentials", func(m *DOBProviderModel) any { return &m.Password }},
+	{"credential_process", "DOB_CREDENTIAL_PROCESS", "credentials", func(m *DOBProviderModel) any { return &m.CredentialProcess }},
+	{"ca_cert_file", "DOB_CA_CERT_FILE", "", func(m *DOBProviderModel) any { return &m.CACertFile }},
+	{"client_cert", "DOB_CLIENT_CERT", "client_certificate", func(m *DOBProviderModel) any { return &m.ClientCert }},
+	{"client_key", "DOB_CLIENT_KEY", "client_certificate", func(m *DOBProviderModel) any { return &m.ClientKey }},
+	{"insecure_skip_verify", "DOB_INSECURE_SKIP_VERIFY", "", func(m *DOBProviderModel) any { return &m.InsecureSkipVerify }},
+	{"http_proxy", "DOB_HTTP_PROXY", "", func(m *DOBProviderModel) any { return &m.HTTPProxy }},
+	{"cache_dir", "DOB_CACHE_DIR", "", func(m *DOBProviderModel) any { return &m.CacheDir }},
+	{"skip_health_check", "DOB_SKIP_HEALTH_CHECK", "", func(m *DOBProviderModel) any { return &m.SkipHealthCheck }},
+}
+
+// settingLayer is one source of settings: the provider block, the
+// environment or a profile.
+type settingLayer struct {
+	// name describes the source in diagnostics, given the setting.
+	name func(externalSetting) string
+	// lookup returns the raw value of a setting and whether it is set.
+	lookup func(externalSetting) (string, bool)
+	// configured reports a setting the provider block sets, whose value is
+	// already in the model.
+	configured bool
+}
+
+// settingSources records where each attribute set outside the provider
+// block came from.
+type settingSources map[string]string
+
+// resolveSettings fills the attributes of config that the provider block
+// leaves unset, from the environment and then from the selected profile of
+// the DOB configuration file, and reports where they came from. Settings
+// of a group are taken from the first source that sets any of them.
+func resolveSettings(ctx context.Context, config *DOBProviderModel, diags *diag.Diagnostics) settingSources {
+	layers := []settingLayer{{
+		name:       func(externalSetting) string { return "the provider block" },
+		configured: true,
+		lookup: func(s externalSetting) (string, bool) {
+			v, ok := s.field(config).(attr.Value)
+			return "", ok && !v.IsNull()
+		},
+	}, {
+		name: func(s externalSetting) string { return "the " + s.envVar + " environment variable" },
+		lookup: func(s externalSetting) (string, bool) {
+			v := os.Getenv(s.envVar)
+			return v, v != ""
+		},
+	}}
+	if profile := loadProfile(config, diags); profile != nil {
+		layers = append(layers, *profile)
+	}
+
+	// A group counts as set in the first layer that sets any member, and
+	// oauth2 is only configurable in the provider block.
+	groupLayer := map[string]int{}
+	if config.OAuth2 != nil {
+		groupLayer["credentials"] = 0
+	}
+	for i, layer := range layers {
+		for _, s := range externalSettings {
+			if _, done := groupLayer[s.group]; s.group == "" || done {
+				continue
+			}
+			if _, ok := layer.lookup(s); ok {
+				groupLayer[s.group] = i
+			}
+		}
+	}
+
+	sources := settingSources{}
+	for _, s := range externalSettings {
+		for i, layer := range layers {
+			if g, ok := groupLayer[s.group]; s.group != "" && (!ok || g != i) {
+				continue
+			}
+			raw, ok := layer.lookup(s)
+			if !ok {
+				continue
+			}
+			if !layer.configured {
+				sources[s.attribute] = layer.name(s)
+				setSetting(config, s, raw, diags)
+			}
+			break
+		}
+	}
+
+	if len(sources) > 0 {
+		fields := map[string]interface{}{}
+		for attribute, source := range sources {
+			fields[attribute+"_source"] = source
+		}
+		tflog.Info(ctx, "Using dob provider settings from outside the provider block", fields)
+	}
+	return sources
+}
+
+// loadProfile returns the layer of the profile selected by the provider
+// block or DOB_PROFILE, or the default profile if it exists. It is nil if
+// there is no profile to use.
+func loadProfile(config *DOBProviderModel, diags *diag.Diagnostics) *settingLayer {
+	name, selected := knownString(config.Profile)
+	if !selected {
+		name = os.Getenv(ProfileEnvVar)
+		selected = name != ""
+	}
+	if !selected {
+		name = profiles.DefaultProfile
+	}
+
+	filePath, err := profiles.DefaultPath()
+	if err != nil {
+		if selected {
+			diags.AddAttributeError(path.Root("profile"), "Unable to Load DOB Profile", err.Error())
+		}
+		return nil
+	}
+	file, err := profiles.Load(filePath)
+	if err != nil {
+		diags.AddError("Unable to Load DOB Profile", err.Error())
+		return nil
+	}
+	settings, ok := file.Profile(name)
+	if !ok {
+		if selected {
+			available := "The file has no profiles."
+			if names := file.Names(); len(names) > 0 {
+				available = "Available profiles: " + strings.Join(names, ", ") + "."
+			}
+			diags.AddAttributeError(path.Root("profile"), "DOB Profile Not Found",
+				fmt.Sprintf("Profile %q is not defined in %s. %s", name, filePath, available))
+		}
+		return nil
+	}
+
+	known := map[string]bool{}
+	for _, s := range externalSettings {
+		known[s.attribute] = true
+	}
+	for key := range settings {
+		if !known[key] {
+			diags.AddWarning("Unknown Setting in DOB Profile",
+				fmt.Sprintf("Profile %q in %s sets %q, which is not a setting profiles can hold; it is ignored.", name, filePath, key))
+		}
+	}
+
+	source := fmt.Sprintf("profile %q in %s", name, filePath)
+	return &settingLayer{
+		name: func(externalSetting) string { return source },
+		lookup: func(s externalSetting) (string, bool) {
+			v, ok := settings[s.attribute]
+			return v, ok && v != ""
+		},
+	}
+}
+
+// setSetting stores raw in config's field for s.
+func setSetting(config *DOBProviderModel, s externalSetting, raw string, diags *diag.Diagnostics) {
+	switch field := s.field(config).(type) {
+	case *types.String:
+		*field = types.StringValue(raw)
+	case *types.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			diags.AddAttributeError(path.Root(s.attribute), "Invalid "+s.attribute,
+				fmt.Sprintf("%s must be true or false, got %q.", s.attribute, raw))
+			return
+		}
+		*field = types.BoolValue(b)
+	case *types.List:
+		var elems []attr.Value
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				elems = append(elems, types.StringValue(v))
+			}
+		}
+		*field = types.ListValueMust(types.StringType, elems)
+	}
+}
+
+// annotate adds to every diagnostic about an attribute set outside the
+// provider block where its value came from, so it can be found.
+func (sources settingSources) annotate(diags diag.Diagnostics) diag.Diagnostics {
+	out := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok {
+			out = append(out, d)
+			continue
+		}
+		steps := withPath.Path().Steps()
+		if len(steps) == 0 {
+			out = append(out, d)
+			continue
+		}
+		name, ok := steps[0].(path.PathStepAttributeName)
+		source, found := sources[string(name)]
+		if !ok || !found {
+			out = append(out, d)
+			continue
+		}
+		detail := fmt.Sprintf("%s\n\nThe value of %s came from %s.", d.Detail(), name, source)
+		if d.Severity() == diag.SeverityError {
+			out = append(out, diag.NewAttributeErrorDiagnostic(withPath.Path(), d.Summary(), detail))
+		} else {
+			out = append(out, diag.NewAttributeWarningDiagnostic(withPath.Path(), d.Summary(), detail))
+		}
+	}
+	return out
+}
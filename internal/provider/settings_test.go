@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-devops/internal/provider/profiles"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// isolateSettings clears the environment variables settings are read from
+// and points the configuration file at one holding content.
+func isolateSettings(t *testing.T, content string) string {
+	t.Helper()
+	for _, s := range externalSettings {
+		t.Setenv(s.envVar, "")
+	}
+	t.Setenv(ProfileEnvVar, "")
+	file := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(profiles.FileEnvVar, file)
+	return file
+}
+
+func TestResolveSettingsPrecedence(t *testing.T) {
+	file := isolateSettings(t, `
+[default]
+endpoint = http://profile.example.com
+cache_dir = /tmp/dob
+skip_health_check = true
+`)
+	t.Setenv("DOB_ENDPOINT", "http://env.example.com")
+	t.Setenv("DOB_SKIP_HEALTH_CHECK", "false")
+
+	config := DOBProviderModel{SkipHealthCheck: types.BoolValue(true)}
+	var diags diag.Diagnostics
+	sources := resolveSettings(context.Background(), &config, &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if got := config.Endpoint.ValueString(); got != "http://env.example.com" {
+		t.Errorf("endpoint = %q, want the environment's", got)
+	}
+	if got := config.CacheDir.ValueString(); got != "/tmp/dob" {
+		t.Errorf("cache_dir = %q, want the profile's", got)
+	}
+	if !config.SkipHealthCheck.ValueBool() {
+		t.Error("skip_health_check = false, want the provider block's true")
+	}
+	want := settingSources{
+		"endpoint":  "the DOB_ENDPOINT environment variable",
+		"cache_dir": `profile "default" in ` + file,
+	}
+	if len(sources) != len(want) || sources["endpoint"] != want["endpoint"] || sources["cache_dir"] != want["cache_dir"] {
+		t.Errorf("sources = %v, want %v", sources, want)
+	}
+}
+
+func TestResolveSettingsCredentialsFromOneSource(t *testing.T) {
+	isolateSettings(t, `
+[ci]
+username = robot
+password = s3cr3t
+`)
+	t.Setenv(ProfileEnvVar, "ci")
+
+	// A token in the environment wins over the profile's basic auth as a
+	// whole, rather than being combined with it.
+	t.Setenv("DOB_TOKEN", "t0ken")
+	config := DOBProviderModel{}
+	var diags diag.Diagnostics
+	resolveSettings(context.Background(), &config, &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if config.Token.ValueString() != "t0ken" || !config.Username.IsNull() || !config.Password.IsNull() {
+		t.Errorf("token %s, username %s, password %s; want only the token", config.Token, config.Username, config.Password)
+	}
+
+	// Without it, the profile provides both.
+	t.Setenv("DOB_TOKEN", "")
+	config = DOBProviderModel{}
+	resolveSettings(context.Background(), &config, &diags)
+	if config.Username.ValueString() != "robot" || config.Password.ValueString() != "s3cr3t" {
+		t.Errorf("username %s, password %s; want the profile's", config.Username, config.Password)
+	}
+}
+
+func TestResolveSettingsEndpointsFromOneSource(t *testing.T) {
+	isolateSettings(t, `
+[default]
+endpoint = http://profile.example.com
+endpoints = http://profile-us.example.com
+`)
+	t.Setenv("DOB_ENDPOINTS", "http://eu.example.com, http://us.example.com/dob,")
+
+	// The environment's failover endpoints come without the profile's
+	// primary one, which belongs to another deployment.
+	config := DOBProviderModel{}
+	var diags diag.Diagnostics
+	resolveSettings(context.Background(), &config, &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !config.Endpoint.IsNull() {
+		t.Errorf("endpoint = %s, want it unset", config.Endpoint)
+	}
+	var endpoints []string
+	diags.Append(config.Endpoints.ElementsAs(context.Background(), &endpoints, false)...)
+	if got := strings.Join(endpoints, " "); got != "http://eu.example.com http://us.example.com/dob" {
+		t.Errorf("endpoints = %q, want the environment's", got)
+	}
+}
+
+func TestResolveSettingsProfileErrors(t *testing.T) {
+	isolateSettings(t, "[staging]\nendpoint = http://staging\ncolour = blue\n")
+
+	var diags diag.Diagnostics
+	resolveSettings(context.Background(), &DOBProviderModel{Profile: types.StringValue("prod")}, &diags)
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "Available profiles: staging.") {
+		t.Errorf("missing profile: %v", diags)
+	}
+
+	diags = nil
+	resolveSettings(context.Background(), &DOBProviderModel{Profile: types.StringValue("staging")}, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("unknown setting: %v, want one warning", diags)
+	}
+
+	// The default profile is optional.
+	diags = nil
+	resolveSettings(context.Background(), &DOBProviderModel{}, &diags)
+	if len(diags) != 0 {
+		t.Errorf("no default profile: %v", diags)
+	}
+}
+
+func TestSettingSourcesAnnotate(t *testing.T) {
+	sources := settingSources{"endpoint": "the DOB_ENDPOINT environment variable"}
+	var diags diag.Diagnostics
+	diags.AddAttributeError(path.Root("endpoint"), "Invalid DOB API Endpoint", "Bad.")
+	diags.AddAttributeError(path.Root("token"), "Invalid token", "Bad.")
+	diags.AddError("Other", "Bad.")
+
+	got := sources.annotate(diags)
+	if len(got) != 3 {
+		t.Fatalf("annotate() returned %d diagnostics, want 3", len(got))
+	}
+	if want := "Bad.\n\nThe value of endpoint came from the DOB_ENDPOINT environment variable."; got[0].Detail() != want {
+		t.Errorf("endpoint detail = %q, want %q", got[0].Detail(), want)
+	}
+	if got[1].Detail() != "Bad." || got[2].Detail() != "Bad." {
+		t.Errorf("other diagnostics changed: %v", got[1:])
+	}
+}
@@ -0,0 +1,255 @@
+// Package resourcetest runs the CRUD methods of the provider's resources
+// directly against an in-process DOB API (see internal/dobserver), without
+// Terraform, so unit tests can exercise how they handle API failures
+// injected with a faultinject.Transport:
+//
+//	resourcetest.RunFaults(t, devs.NewDevResource, fixture, map[string]resourcetest.FaultCase{
+//		"update fails": {Op: resourcetest.Update, Method: "PUT", Path: "/dev/BBBBB",
+//			Fault: faultinject.Fault{StatusCode: 500}, Summary: "Error Updating Dev"},
+//	})
+package resourcetest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-devops/internal/dobserver"
+	"terraform-provider-devops/internal/faultinject"
+	"terraform-provider-devops/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Seed returns what the API holds at the start of each test: engineer
+// AAAAA, Dev group BBBBB and Ops group CCCCC of that engineer, and DevOps
+// group DDDDD of both groups.
+func Seed() *dobserver.Snapshot {
+	return &dobserver.Snapshot{
+		Engineers: []dobserver.Engineer{{ID: "AAAAA", Name: "Ann", Email: "ann@example.com"}},
+		Devs:      []dobserver.Group{{ID: "BBBBB", Name: "Platform", Engineers: []string{"AAAAA"}}},
+		Ops:       []dobserver.Group{{ID: "CCCCC", Name: "Platform", Engineers: []string{"AAAAA"}}},
+		DevOps:    []dobserver.DevOpsGroup{{ID: "DDDDD", Dev: []string{"BBBBB"}, Ops: []string{"CCCCC"}}},
+	}
+}
+
+// Resource is a resource under test, configured with a client of an API
+// of its own.
+type Resource struct {
+	resource.Resource
+	Schema schema.Schema
+	// Client talks to the same API without faults, to set up tests.
+	Client *client.Client
+}
+
+// New returns the resource newResource creates, talking to a fresh API
+// holding Seed through ft, or directly if ft is nil.
+func New(t *testing.T, newResource func() resource.Resource, ft *faultinject.Transport) *Resource {
+	t.Helper()
+	srv, err := dobserver.New(dobserver.Options{Seed: Seed()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	opts := []client.Option{client.WithRetry(2, time.Millisecond)}
+	plain, err := client.NewClient(&ts.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ft != nil {
+		opts = append(opts, client.WithTransportWrapper(ft.Wrap))
+	}
+	c, err := client.NewClient(&ts.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &Resource{Resource: newResource(), Client: plain}
+	r.Resource.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	r.Schema = schemaResp.Schema
+	return r
+}
+
+// Attrs gives attributes of a resource object by name: a string, a
+// []string for a list, a map[string]string for an object such as the
+// timeouts block, tftypes.UnknownValue, or a tftypes.Value. Attributes
+// left out are null.
+type Attrs map[string]any
+
+// Value returns the object of r's schema with the given attributes.
+func (r *Resource) Value(t *testing.T, attrs Attrs) tftypes.Value {
+	t.Helper()
+	typ := r.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = value(t, name, attrType, attrs[name])
+	}
+	for name := range attrs {
+		if _, ok := typ.AttributeTypes[name]; !ok {
+			t.Fatalf("resource has no attribute %q", name)
+		}
+	}
+	return tftypes.NewValue(typ, values)
+}
+
+func value(t *testing.T, name string, typ tftypes.Type, v any) tftypes.Value {
+	t.Helper()
+	if v == tftypes.UnknownValue {
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+	switch v := v.(type) {
+	case nil:
+		return tftypes.NewValue(typ, nil)
+	case tftypes.Value:
+		return v
+	case string:
+		return tftypes.NewValue(typ, v)
+	case []string:
+		elems := make([]tftypes.Value, len(v))
+		for i, s := range v {
+			elems[i] = tftypes.NewValue(tftypes.String, s)
+		}
+		return tftypes.NewValue(typ, elems)
+	case map[string]string:
+		obj := typ.(tftypes.Object)
+		fields := make(map[string]tftypes.Value, len(obj.AttributeTypes))
+		for field, fieldType := range obj.AttributeTypes {
+			fields[field] = tftypes.NewValue(fieldType, nil)
+			if s, ok := v[field]; ok {
+				fields[field] = tftypes.NewValue(fieldType, s)
+			}
+		}
+		return tftypes.NewValue(typ, fields)
+	}
+	t.Fatalf("attribute %q: unsupported value %#v", name, v)
+	return tftypes.Value{}
+}
+
+// Create runs r's Create with plan and returns its response.
+func (r *Resource) Create(t *testing.T, plan Attrs) resource.CreateResponse {
+	t.Helper()
+	ctx := context.Background()
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: r.Schema, Raw: r.Value(t, plan)}}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: r.Schema, Raw: tftypes.NewValue(r.Schema.Type().TerraformType(ctx), nil)}}
+	r.Resource.Create(ctx, req, &resp)
+	return resp
+}
+
+// Read runs r's Read with state and returns its response.
+func (r *Resource) Read(t *testing.T, state Attrs) resource.ReadResponse {
+	t.Helper()
+	prior := r.Value(t, state)
+	resp := resource.ReadResponse{State: tfsdk.State{Schema: r.Schema, Raw: prior.Copy()}}
+	r.Resource.Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: r.Schema, Raw: prior}}, &resp)
+	return resp
+}
+
+// Update runs r's Update from state to plan and returns its response.
+func (r *Resource) Update(t *testing.T, state, plan Attrs) resource.UpdateResponse {
+	t.Helper()
+	prior := r.Value(t, state)
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: r.Schema, Raw: r.Value(t, plan)},
+		State: tfsdk.State{Schema: r.Schema, Raw: prior},
+	}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: r.Schema, Raw: prior.Copy()}}
+	r.Resource.Update(context.Background(), req, &resp)
+	return resp
+}
+
+// Op is a resource operation a FaultCase applies to.
+type Op int
+
+const (
+	Create Op = iota + 1
+	Read
+	Update
+)
+
+// FaultCase is a fault injected into one operation of a resource and the
+// error the operation must report.
+type FaultCase struct {
+	Op Op
+	// Method and Path select the request that fails, on every attempt.
+	// Without them every request fails.
+	Method, Path string
+	Fault        faultinject.Fault
+	// Summary is the summary of the error, and Detail a part of its
+	// detail unless empty.
+	Summary, Detail string
+}
+
+// Fixture holds the objects the operations of fault cases work on.
+type Fixture struct {
+	// Create is the plan of Create.
+	Create Attrs
+	// State is the prior state of Read and Update, an object of Seed.
+	State Attrs
+	// Update is the plan of Update. As in the plans Terraform sends, its
+	// computed attributes such as id are tftypes.UnknownValue.
+	Update Attrs
+}
+
+// RunFaults runs each case against a fresh API and checks that the
+// operation reports the expected error and writes no state it could not
+// confirm: none after a failed Create, and the prior state after a failed
+// Read or Update.
+func RunFaults(t *testing.T, newResource func() resource.Resource, fx Fixture, cases map[string]FaultCase) {
+	t.Helper()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ft := &faultinject.Transport{}
+			if tc.Method == "" {
+				ft.Default = tc.Fault
+			} else {
+				ft.Script(tc.Method, tc.Path, tc.Fault, tc.Fault)
+			}
+			r := New(t, newResource, ft)
+
+			var (
+				diags diag.Diagnostics
+				state tftypes.Value
+				want  tftypes.Value
+			)
+			switch tc.Op {
+			case Create:
+				resp := r.Create(t, fx.Create)
+				diags, state = resp.Diagnostics, resp.State.Raw
+				want = tftypes.NewValue(r.Schema.Type().TerraformType(context.Background()), nil)
+			case Read:
+				resp := r.Read(t, fx.State)
+				diags, state, want = resp.Diagnostics, resp.State.Raw, r.Value(t, fx.State)
+			case Update:
+				resp := r.Update(t, fx.State, fx.Update)
+				diags, state, want = resp.Diagnostics, resp.State.Raw, r.Value(t, fx.State)
+			default:
+				t.Fatalf("unknown operation %d", tc.Op)
+			}
+
+			if !diags.HasError() {
+				t.Fatal("want error diagnostic")
+			}
+			err := diags.Errors()[0]
+			if err.Summary() != tc.Summary {
+				t.Errorf("summary = %q, want %q", err.Summary(), tc.Summary)
+			}
+			if !strings.Contains(err.Detail(), tc.Detail) {
+				t.Errorf("detail = %q, want it to mention %q", err.Detail(), tc.Detail)
+			}
+			if !state.Equal(want) {
+				t.Errorf("state = %v, want %v", state, want)
+			}
+		})
+	}
+}